@@ -3,11 +3,9 @@ package example
 import (
 	"context"
 	"fmt"
-	"os"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/sns"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/cucumber/godog"
@@ -18,7 +16,7 @@ import (
 )
 
 type test struct {
-	awsEndpoint string
+	awsConfig aws.Config
 
 	topicArn       string
 	topicMessageId string
@@ -43,20 +41,14 @@ func TestFeatures(t *testing.T) {
 
 func initializeScenario(ctx *godog.ScenarioContext) {
 	ctx.Before(func(ctx context.Context, sc *godog.Scenario) (context.Context, error) {
-		os.Setenv("AWS_ACCESS_KEY_ID", "test")
-		os.Setenv("AWS_SECRET_ACCESS_KEY", "test")
-		os.Setenv("AWS_SESSION_TOKEN", "test")
-
 		definition := container.NewContainerDefinition(
-			localstack.WithLocalStackContainer(),
-			container.WithEnvVars(map[string]string{
-				"SERVICES": "sns,sqs",
-			}),
+			localstack.WithLocalStackContainer(
+				localstack.WithServices(localstack.SNS, localstack.SQS),
+			),
 			container.WithExecutableFiles(
 				localstack.BasePath,
 				"./testdata/init-sns.sh",
 				"./testdata/init-sqs.sh",
-				"./testdata/z-init.sh",
 			),
 		)
 
@@ -65,13 +57,13 @@ func initializeScenario(ctx *godog.ScenarioContext) {
 			return ctx, err
 		}
 
-		awsEndpoint, err := localstack.BuildEndpoint(ctx, localStackContainer)
+		awsConfig, err := localstack.AWSConfig(ctx, localStackContainer)
 		if err != nil {
 			return ctx, err
 		}
 
 		currentState := testState.Retrieve(ctx)
-		currentState.awsEndpoint = awsEndpoint
+		currentState.awsConfig = awsConfig
 
 		containers[sc.Id] = container.BuildGroupContainer(
 			container.WithDockerContainer(localStackContainer),
@@ -99,14 +91,7 @@ func initializeScenario(ctx *godog.ScenarioContext) {
 func iHaveAnSNSTopicNamed(ctx context.Context, topicName string) (context.Context, error) {
 	currentState := testState.Retrieve(ctx)
 
-	cloudConfig, err := awsCfg(ctx, currentState.awsEndpoint)
-	if err != nil {
-		return ctx, fmt.Errorf("failed to load AWS default config: %w", err)
-	}
-
-	cloudConfig.BaseEndpoint = &currentState.awsEndpoint
-
-	client := sns.NewFromConfig(cloudConfig)
+	client := sns.NewFromConfig(currentState.awsConfig)
 
 	output, err := client.ListTopics(ctx, &sns.ListTopicsInput{})
 	if err != nil {
@@ -135,14 +120,7 @@ func iHaveAnSNSTopicNamed(ctx context.Context, topicName string) (context.Contex
 func iHaveAnSQSQueueNamed(ctx context.Context, queueName string) (context.Context, error) {
 	currentState := testState.Retrieve(ctx)
 
-	cloudConfig, err := awsCfg(ctx, currentState.awsEndpoint)
-	if err != nil {
-		return ctx, fmt.Errorf("failed to load AWS default config: %w", err)
-	}
-
-	cloudConfig.BaseEndpoint = &currentState.awsEndpoint
-
-	client := sqs.NewFromConfig(cloudConfig)
+	client := sqs.NewFromConfig(currentState.awsConfig)
 
 	output, err := client.ListQueues(ctx, &sqs.ListQueuesInput{})
 	if err != nil {
@@ -171,14 +149,7 @@ func iHaveAnSQSQueueNamed(ctx context.Context, queueName string) (context.Contex
 func iPublishAMessageIntoTheTopic(ctx context.Context) (context.Context, error) {
 	currentState := testState.Retrieve(ctx)
 
-	cloudConfig, err := awsCfg(ctx, currentState.awsEndpoint)
-	if err != nil {
-		return ctx, fmt.Errorf("failed to load AWS default config: %w", err)
-	}
-
-	cloudConfig.BaseEndpoint = &currentState.awsEndpoint
-
-	client := sns.NewFromConfig(cloudConfig)
+	client := sns.NewFromConfig(currentState.awsConfig)
 
 	output, err := client.Publish(ctx, &sns.PublishInput{
 		TopicArn: &currentState.topicArn,
@@ -196,14 +167,7 @@ func iPublishAMessageIntoTheTopic(ctx context.Context) (context.Context, error)
 func iPublishAMessageIntoTheQueue(ctx context.Context) (context.Context, error) {
 	currentState := testState.Retrieve(ctx)
 
-	cloudConfig, err := awsCfg(ctx, currentState.awsEndpoint)
-	if err != nil {
-		return ctx, fmt.Errorf("failed to load AWS default config: %w", err)
-	}
-
-	cloudConfig.BaseEndpoint = &currentState.awsEndpoint
-
-	client := sqs.NewFromConfig(cloudConfig)
+	client := sqs.NewFromConfig(currentState.awsConfig)
 
 	output, err := client.SendMessage(ctx, &sqs.SendMessageInput{
 		QueueUrl:    &currentState.queueUrl,
@@ -221,14 +185,7 @@ func iPublishAMessageIntoTheQueue(ctx context.Context) (context.Context, error)
 func iReadTheMessagesFromTheQueue(ctx context.Context) (context.Context, error) {
 	currentState := testState.Retrieve(ctx)
 
-	cloudConfig, err := awsCfg(ctx, currentState.awsEndpoint)
-	if err != nil {
-		return ctx, fmt.Errorf("failed to load AWS default config: %w", err)
-	}
-
-	cloudConfig.BaseEndpoint = &currentState.awsEndpoint
-
-	client := sqs.NewFromConfig(cloudConfig)
+	client := sqs.NewFromConfig(currentState.awsConfig)
 
 	output, err := client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
 		QueueUrl:            &currentState.queueUrl,
@@ -272,14 +229,3 @@ func theMessageShouldBePublishedIntoTheQueue(ctx context.Context) (context.Conte
 
 	return ctx, nil
 }
-
-func awsCfg(ctx context.Context, endpointURL string) (aws.Config, error) {
-	cfg, err := config.LoadDefaultConfig(ctx,
-		config.WithRegion("us-east-1"),
-	)
-	if err != nil {
-		return cfg, fmt.Errorf("failed to load AWS default config: %w", err)
-	}
-	cfg.BaseEndpoint = &endpointURL
-	return cfg, nil
-}
@@ -2,6 +2,7 @@ package container
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/testcontainers/testcontainers-go"
 )
@@ -10,8 +11,12 @@ import (
 type GroupContainer struct {
 	Network    *testcontainers.DockerNetwork
 	Containers []testcontainers.Container
+	ResetHooks []ResetFunc
 }
 
+// ResetFunc is a type that represents a function that resets the state of a shared container
+type ResetFunc func(ctx context.Context) error
+
 // TestContainersOption is a type that represents a test context option
 type TestContainersOption func(*GroupContainer)
 
@@ -29,6 +34,14 @@ func WithDockerContainer(container ...testcontainers.Container) TestContainersOp
 	}
 }
 
+// WithResetHook is a TestContainersOption that adds the functions that reset the state of the containers
+// between scenarios, they are called in order by ResetGroup
+func WithResetHook(hooks ...ResetFunc) TestContainersOption {
+	return func(containers *GroupContainer) {
+		containers.ResetHooks = append(containers.ResetHooks, hooks...)
+	}
+}
+
 // NewGroup creates a new map of test contexts to store a group of containers
 func NewGroup() map[string]GroupContainer {
 	return make(map[string]GroupContainer)
@@ -62,3 +75,20 @@ func DestroyGroup(ctx context.Context, group GroupContainer) (context.Context, e
 
 	return ctx, nil
 }
+
+// ResetGroup resets the state of the given group of containers by calling its reset hooks, without restarting them
+//
+// Example:
+//
+//	ctx.Before(func(ctx context.Context, sc *godog.Scenario) (context.Context, error) {
+//		return container.ResetGroup(ctx, sharedGroup)
+//	})
+func ResetGroup(ctx context.Context, group GroupContainer) (context.Context, error) {
+	for _, hook := range group.ResetHooks {
+		if err := hook(ctx); err != nil {
+			return ctx, fmt.Errorf("failed to reset the group: %w", err)
+		}
+	}
+
+	return ctx, nil
+}
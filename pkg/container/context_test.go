@@ -0,0 +1,61 @@
+package container_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResetGroup(t *testing.T) {
+	t.Run("Should call the reset hooks in order", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		var calls []string
+		group := container.BuildGroupContainer(
+			container.WithResetHook(func(ctx context.Context) error {
+				calls = append(calls, "first")
+				return nil
+			}),
+			container.WithResetHook(func(ctx context.Context) error {
+				calls = append(calls, "second")
+				return nil
+			}),
+		)
+
+		// Act
+		_, err := container.ResetGroup(ctx, group)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"first", "second"}, calls)
+	})
+
+	t.Run("Should stop at the first failing reset hook", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		called := false
+		group := container.BuildGroupContainer(
+			container.WithResetHook(
+				func(ctx context.Context) error {
+					return errors.New("boom")
+				},
+				func(ctx context.Context) error {
+					called = true
+					return nil
+				},
+			),
+		)
+
+		// Act
+		_, err := container.ResetGroup(ctx, group)
+
+		// Assert
+		assert.Error(t, err)
+		assert.False(t, called)
+	})
+}
@@ -0,0 +1,211 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// maxReportedRecords is the number of closest records reported when the expected records are not received
+const maxReportedRecords int = 3
+
+// metadataTimeout is the time given to the brokers to tell whether a topic exists once the records were awaited
+const metadataTimeout time.Duration = 5 * time.Second
+
+// Client is a type that represents the Kafka helpers: topics, producing and assertions
+type Client struct {
+	Kafka *kgo.Client
+
+	brokers string
+	opts    []kgo.Opt
+}
+
+// New returns the Kafka helpers for the given brokers, the options being given to every underlying client
+//
+// Example:
+//
+//	brokers, err := kafka.BuildExternalAddress(ctx, kafkaContainer)
+//	if err != nil {
+//		return ctx, err
+//	}
+//
+//	client, err := kafka.New(brokers)
+//	if err != nil {
+//		return ctx, err
+//	}
+//	defer client.Close()
+func New(brokers string, opts ...kgo.Opt) (*Client, error) {
+	kafkaClient, err := kgo.NewClient(append([]kgo.Opt{kgo.SeedBrokers(brokers)}, opts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the client: %w", err)
+	}
+
+	return &Client{
+		Kafka:   kafkaClient,
+		brokers: brokers,
+		opts:    opts,
+	}, nil
+}
+
+// Close closes the underlying client
+func (c *Client) Close() {
+	c.Kafka.Close()
+}
+
+// Produce produces the given records to the topic and waits for them to be acknowledged
+//
+// Example:
+//
+//	err := client.Produce(ctx, "orders", kafka.Record{
+//		Key:     "123",
+//		Value:   `{"type": "order_created"}`,
+//		Headers: map[string]string{"eventType": "order_created"},
+//	})
+func (c *Client) Produce(ctx context.Context, topic string, records ...Record) error {
+	kafkaRecords := make([]*kgo.Record, len(records))
+	for i, record := range records {
+		kafkaRecords[i] = record.kafkaRecord(topic)
+	}
+
+	if err := c.Kafka.ProduceSync(ctx, kafkaRecords...).FirstErr(); err != nil {
+		return fmt.Errorf("failed to produce to the topic '%s': %w", topic, err)
+	}
+
+	return nil
+}
+
+// AwaitRecords consumes the topic from the beginning until at least n records matching all the given matchers are
+// received, or returns a MismatchError when the timeout is reached
+//
+// The consumption stops once n records matched, so the returned records may exceed n when a single fetch brings
+// more, and the records produced afterwards are not checked.
+//
+// A non-retriable fetch error, like a missing authorization, is returned at once, and a topic that still does not
+// exist when the timeout is reached is reported as such instead of a MismatchError.
+//
+// Example:
+//
+//	records, err := client.AwaitRecords(ctx, "orders", 1, 10*time.Second,
+//		kafka.MatchKey("123"),
+//		kafka.MatchJSON(`{"type": "order_created"}`),
+//	)
+func (c *Client) AwaitRecords(ctx context.Context, topic string, n int, timeout time.Duration, matchers ...Matcher) ([]Record, error) {
+	parent := ctx
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	consumer, err := kgo.NewClient(append([]kgo.Opt{
+		kgo.SeedBrokers(c.brokers),
+		kgo.ConsumeTopics(topic),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+	}, c.opts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the consumer: %w", err)
+	}
+	defer consumer.Close()
+
+	var matched []Record
+	var mismatches []mismatch
+
+	for len(matched) < n && ctx.Err() == nil {
+		fetches := consumer.PollFetches(ctx)
+
+		if err := fetchError(fetches); err != nil {
+			return matched, fmt.Errorf("failed to consume the topic '%s': %w", topic, err)
+		}
+
+		fetches.EachRecord(func(kafkaRecord *kgo.Record) {
+			record := parseRecord(kafkaRecord)
+
+			diffs := Match(record, matchers...)
+			if len(diffs) > 0 {
+				mismatches = append(mismatches, mismatch{partition: record.Partition, offset: record.Offset, diffs: diffs})
+				return
+			}
+
+			matched = append(matched, record)
+		})
+	}
+
+	if len(matched) >= n {
+		return matched, nil
+	}
+
+	if len(matched) == 0 && len(mismatches) == 0 {
+		if err := c.topicExists(context.WithoutCancel(parent), topic); err != nil {
+			return nil, err
+		}
+	}
+
+	// the records are ordered by position first, so the records with as many differences keep a stable order
+	sort.Slice(mismatches, func(i, j int) bool {
+		if mismatches[i].partition != mismatches[j].partition {
+			return mismatches[i].partition < mismatches[j].partition
+		}
+		return mismatches[i].offset < mismatches[j].offset
+	})
+	sort.SliceStable(mismatches, func(i, j int) bool {
+		return len(mismatches[i].diffs) < len(mismatches[j].diffs)
+	})
+
+	closest := make([][]string, 0, maxReportedRecords)
+	for _, mismatch := range mismatches[:min(len(mismatches), maxReportedRecords)] {
+		closest = append(closest, mismatch.diffs)
+	}
+
+	return matched, &MismatchError{
+		Topic:    topic,
+		Expected: n,
+		Received: len(matched),
+		Closest:  closest,
+	}
+}
+
+// mismatch is a received record that did not match, with its differences
+type mismatch struct {
+	partition int32
+	offset    int64
+	diffs     []string
+}
+
+// fetchError returns the first error of the fetches that retrying will not solve, ignoring the context errors
+// and the data losses the client recovers from
+func fetchError(fetches kgo.Fetches) error {
+	for _, fetchErr := range fetches.Errors() {
+		var dataLoss *kgo.ErrDataLoss
+		switch {
+		case errors.Is(fetchErr.Err, context.Canceled), errors.Is(fetchErr.Err, context.DeadlineExceeded):
+		case errors.As(fetchErr.Err, &dataLoss):
+		case kerr.IsRetriable(fetchErr.Err):
+		default:
+			return fetchErr.Err
+		}
+	}
+
+	return nil
+}
+
+// topicExists returns an error when the metadata of the brokers does not know the topic
+func (c *Client) topicExists(ctx context.Context, topic string) error {
+	ctx, cancel := context.WithTimeout(ctx, metadataTimeout)
+	defer cancel()
+
+	topics, err := kadm.NewClient(c.Kafka).ListTopics(ctx, topic)
+	if err != nil {
+		// the records are then reported as missing
+		return nil
+	}
+
+	if detail, ok := topics[topic]; ok && errors.Is(detail.Err, kerr.UnknownTopicOrPartition) {
+		return fmt.Errorf("the topic '%s' does not exist", topic)
+	}
+
+	return nil
+}
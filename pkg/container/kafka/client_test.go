@@ -0,0 +1,107 @@
+package kafka_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+func TestAwaitRecords(t *testing.T) {
+	t.Run("Should return the matching records", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		client := newFakeClient(t)
+
+		require.NoError(t, client.CreateTopics(ctx, kafka.Topic{Name: "orders", Partitions: 1}))
+		require.NoError(t, client.Produce(ctx, "orders",
+			kafka.Record{Key: "1", Value: `{"type": "order_created"}`},
+			kafka.Record{Key: "2", Value: `{"type": "order_paid"}`},
+		))
+
+		// Act
+		records, err := client.AwaitRecords(ctx, "orders", 1, 5*time.Second, kafka.MatchJSON(`{"type": "order_paid"}`))
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, "2", records[0].Key)
+	})
+
+	t.Run("Should report the closest records by differences then by position", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		client := newFakeClient(t)
+
+		require.NoError(t, client.CreateTopics(ctx, kafka.Topic{Name: "orders", Partitions: 1}))
+		require.NoError(t, client.Produce(ctx, "orders",
+			kafka.Record{Value: `{"type": "b", "id": 2}`},
+			kafka.Record{Value: `{"type": "b", "id": 1}`},
+			kafka.Record{Value: `{"type": "c", "id": 1}`},
+			kafka.Record{Value: `{"type": "a", "id": 3}`},
+		))
+
+		// Act
+		_, err := client.AwaitRecords(ctx, "orders", 1, time.Second, kafka.MatchJSON(`{"type": "a", "id": 1}`))
+
+		// Assert
+		var mismatchErr *kafka.MismatchError
+		require.ErrorAs(t, err, &mismatchErr)
+		assert.Equal(t, 0, mismatchErr.Received)
+		require.Len(t, mismatchErr.Closest, 3)
+		assert.Contains(t, mismatchErr.Closest[0][0], `"b"`)
+		assert.Contains(t, mismatchErr.Closest[1][0], `"c"`)
+		assert.Contains(t, mismatchErr.Closest[2][0], "3")
+	})
+
+	t.Run("Should report a topic that does not exist", func(t *testing.T) {
+		// Arrange
+		client := newFakeClient(t)
+
+		// Act
+		records, err := client.AwaitRecords(context.Background(), "missing", 1, 500*time.Millisecond)
+
+		// Assert
+		assert.EqualError(t, err, "the topic 'missing' does not exist")
+		assert.Empty(t, records)
+	})
+
+	t.Run("Should return the non-retriable fetch errors at once", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		cluster, client := newFakeCluster(t)
+
+		require.NoError(t, client.CreateTopics(ctx, kafka.Topic{Name: "orders", Partitions: 1}))
+		cluster.ControlKey(int16(kmsg.Fetch), func(req kmsg.Request) (kmsg.Response, error, bool) {
+			fetch := req.(*kmsg.FetchRequest)
+			resp := fetch.ResponseKind().(*kmsg.FetchResponse)
+			for _, topic := range fetch.Topics {
+				respTopic := kmsg.NewFetchResponseTopic()
+				respTopic.Topic = topic.Topic
+				respTopic.TopicID = topic.TopicID
+				for _, partition := range topic.Partitions {
+					respPartition := kmsg.NewFetchResponseTopicPartition()
+					respPartition.Partition = partition.Partition
+					respPartition.ErrorCode = kerr.TopicAuthorizationFailed.Code
+					respTopic.Partitions = append(respTopic.Partitions, respPartition)
+				}
+				resp.Topics = append(resp.Topics, respTopic)
+			}
+			return resp, nil, true
+		})
+
+		// Act
+		start := time.Now()
+		_, err := client.AwaitRecords(ctx, "orders", 1, 10*time.Second)
+
+		// Assert
+		assert.ErrorIs(t, err, kerr.TopicAuthorizationFailed)
+		assert.ErrorContains(t, err, "failed to consume the topic 'orders'")
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+}
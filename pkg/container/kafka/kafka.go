@@ -0,0 +1,309 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/jfelipearaujo/testcontainers/pkg/network"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	KRaftImage     string = "apache/kafka:3.7.0"
+	RedpandaImage  string = "docker.redpanda.com/redpandadata/redpanda:v23.3.13"
+	ExposedPort    string = "9093"
+	BrokerPort     string = "9092"
+	ControllerPort string = "9094"
+	ClusterID      string = "MkU3OEVBNTcwNTJENDM2Qk"
+
+	// starterScriptPath is the script written once the container is started, when the mapped port is known,
+	// so the broker advertises the right listener to the host clients
+	starterScriptPath string = "/tmp/testcontainers_start.sh"
+)
+
+// Flavor is a type that represents the broker implementation of the Kafka container
+type Flavor string
+
+const (
+	// KRaft is an Apache Kafka broker running in KRaft mode, without ZooKeeper
+	KRaft Flavor = "kraft"
+	// Redpanda is a Redpanda broker, compatible with the Kafka API, with a built-in Schema Registry
+	Redpanda Flavor = "redpanda"
+)
+
+// Options is a type that represents the options for a Kafka container
+//
+//	Default options:
+//		Flavor: "kraft"
+//		Image: "apache/kafka:3.7.0" (KRaft) or "docker.redpanda.com/redpandadata/redpanda:v23.3.13" (Redpanda)
+//		ExposedPort: "9093"
+//		Topics: nil
+//		SchemaRegistry: false
+//
+//	Default network alias: nil
+type Options struct {
+	Flavor         Flavor
+	Image          string
+	ExposedPort    string
+	Topics         []Topic
+	SchemaRegistry bool
+	NetworkAlias   *string
+}
+
+// KafkaOption is a type that represents a Kafka option
+type KafkaOption func(*Options)
+
+// WithFlavor is a KafkaOption that sets the broker implementation of the Kafka container
+//
+//	Default: "kraft"
+func WithFlavor(flavor Flavor) KafkaOption {
+	return func(options *Options) {
+		options.Flavor = flavor
+	}
+}
+
+// WithImage is a KafkaOption that sets the image of the Kafka container, it must match the flavor
+//
+//	Default: "apache/kafka:3.7.0" (KRaft) or "docker.redpanda.com/redpandadata/redpanda:v23.3.13" (Redpanda)
+func WithImage(image string) KafkaOption {
+	return func(options *Options) {
+		options.Image = image
+	}
+}
+
+// WithExposedPort is a KafkaOption that sets the port of the listener used by the host clients
+//
+//	Default: "9093"
+func WithExposedPort(exposedPort string) KafkaOption {
+	return func(options *Options) {
+		options.ExposedPort = exposedPort
+	}
+}
+
+// WithTopics is a KafkaOption that creates the given topics once the container is ready
+//
+//	Default: nil
+//
+// Example:
+//
+//	kafka.WithTopics(
+//		kafka.Topic{Name: "orders", Partitions: 3},
+//		kafka.Topic{Name: "orders-compacted", Config: map[string]string{"cleanup.policy": "compact"}},
+//	)
+func WithTopics(topics ...Topic) KafkaOption {
+	return func(options *Options) {
+		options.Topics = append(options.Topics, topics...)
+	}
+}
+
+// WithSchemaRegistry is a KafkaOption that exposes the built-in Schema Registry of the Redpanda flavor,
+// the KRaft flavor needing a separate container, see WithSchemaRegistryContainer
+//
+//	Default: false
+func WithSchemaRegistry(schemaRegistry bool) KafkaOption {
+	return func(options *Options) {
+		options.SchemaRegistry = schemaRegistry
+	}
+}
+
+// WithNetwork is a KafkaOption that sets the network alias of the Kafka container
+//
+//	Default: nil
+func WithNetwork(network *network.Network) KafkaOption {
+	return func(options *Options) {
+		options.NetworkAlias = &network.Alias
+	}
+}
+
+// Return the address of the broker for the given container with default options when the container is in a network
+//
+//	Example: "network_alias:9092"
+func BuildInternalAddress(ctx context.Context, container testcontainers.Container, opts ...KafkaOption) (string, error) {
+	options := buildOptions(opts...)
+
+	if options.NetworkAlias == nil {
+		return "", fmt.Errorf("the container is not in a network")
+	}
+
+	return net.JoinHostPort(*options.NetworkAlias, BrokerPort), nil
+}
+
+// Return the address of the broker for the given container with default options when the container is NOT in a network
+//
+//	Example: "localhost:49153"
+func BuildExternalAddress(ctx context.Context, container testcontainers.Container, opts ...KafkaOption) (string, error) {
+	options := buildOptions(opts...)
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the host: %w", err)
+	}
+
+	port, err := container.MappedPort(ctx, nat.Port(options.ExposedPort))
+	if err != nil {
+		return "", fmt.Errorf("failed to get the mapped port: %w", err)
+	}
+
+	return net.JoinHostPort(host, port.Port()), nil
+}
+
+func buildOptions(opts ...KafkaOption) *Options {
+	options := &Options{
+		Flavor:      KRaft,
+		ExposedPort: ExposedPort,
+	}
+
+	for _, o := range opts {
+		o(options)
+	}
+
+	if options.Image == "" {
+		options.Image = KRaftImage
+		if options.Flavor == Redpanda {
+			options.Image = RedpandaImage
+		}
+	}
+
+	return options
+}
+
+// Return a new container definition for a single node Kafka container with default options
+//
+//	DockerImage: "apache/kafka:3.7.0"
+//	Exposed ports: "9093" (host clients)
+//	Listeners:
+//		PLAINTEXT: "0.0.0.0:9093", advertised as the mapped port of the host
+//		BROKER: "0.0.0.0:9092", advertised as the network alias, or the container hostname
+//
+//	WaitingForBroker: metadata request
+//	StartupTimeout: "60 seconds"
+//
+// The container waits for a starter script written once it is started, so the advertised listeners contain
+// the mapped port. The same KafkaOptions must be given to the address builders.
+//
+// Example:
+//
+//	definition := container.NewContainerDefinition(
+//		container.WithNetwork(ntwrkDefinition.Alias, network),
+//		kafka.WithKafkaContainer(
+//			kafka.WithFlavor(kafka.Redpanda),
+//			kafka.WithSchemaRegistry(true),
+//			kafka.WithTopics(kafka.Topic{Name: "orders", Partitions: 3}),
+//			kafka.WithNetwork(ntwrkDefinition),
+//		),
+//	)
+func WithKafkaContainer(opts ...KafkaOption) container.ContainerOption {
+	options := buildOptions(opts...)
+
+	if options.SchemaRegistry && options.Flavor != Redpanda {
+		panic(fmt.Errorf("the %s flavor has no built-in schema registry, use WithSchemaRegistryContainer", options.Flavor))
+	}
+
+	return func(container *container.Container) {
+		container.ContainerRequest.Image = options.Image
+		container.ContainerRequest.ExposedPorts = []string{
+			options.ExposedPort,
+		}
+		container.ContainerRequest.Entrypoint = []string{"sh", "-c"}
+		container.ContainerRequest.Cmd = []string{
+			fmt.Sprintf("while [ ! -f %[1]s ]; do sleep 0.1; done; exec sh %[1]s", starterScriptPath),
+		}
+
+		switch options.Flavor {
+		case Redpanda:
+			if options.SchemaRegistry {
+				container.ContainerRequest.ExposedPorts = append(container.ContainerRequest.ExposedPorts, SchemaRegistryPort)
+			}
+		default:
+			container.ContainerRequest.Env = kraftEnv(options)
+		}
+
+		container.ContainerRequest.LifecycleHooks = append(container.ContainerRequest.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+			PostStarts: []testcontainers.ContainerHook{
+				func(ctx context.Context, target testcontainers.Container) error {
+					return copyStarterScript(ctx, target, options)
+				},
+			},
+		})
+
+		container.ContainerRequest.WaitingFor = ForBroker(opts...).
+			WithStartupTimeout(60 * time.Second)
+
+		if len(options.Topics) > 0 {
+			withTopics(container, opts...)
+		}
+	}
+}
+
+// kraftEnv returns the configuration of a single node acting as broker and controller, the advertised
+// listeners being exported by the starter script
+func kraftEnv(options *Options) map[string]string {
+	port := nat.Port(options.ExposedPort).Port()
+
+	return map[string]string{
+		"CLUSTER_ID":                                     ClusterID,
+		"KAFKA_NODE_ID":                                  "1",
+		"KAFKA_PROCESS_ROLES":                            "broker,controller",
+		"KAFKA_CONTROLLER_QUORUM_VOTERS":                 "1@localhost:" + ControllerPort,
+		"KAFKA_LISTENERS":                                fmt.Sprintf("PLAINTEXT://0.0.0.0:%s,BROKER://0.0.0.0:%s,CONTROLLER://0.0.0.0:%s", port, BrokerPort, ControllerPort),
+		"KAFKA_LISTENER_SECURITY_PROTOCOL_MAP":           "PLAINTEXT:PLAINTEXT,BROKER:PLAINTEXT,CONTROLLER:PLAINTEXT",
+		"KAFKA_INTER_BROKER_LISTENER_NAME":               "BROKER",
+		"KAFKA_CONTROLLER_LISTENER_NAMES":                "CONTROLLER",
+		"KAFKA_OFFSETS_TOPIC_REPLICATION_FACTOR":         "1",
+		"KAFKA_TRANSACTION_STATE_LOG_REPLICATION_FACTOR": "1",
+		"KAFKA_TRANSACTION_STATE_LOG_MIN_ISR":            "1",
+		"KAFKA_GROUP_INITIAL_REBALANCE_DELAY_MS":         "0",
+	}
+}
+
+// starterScript returns the script that starts the broker, advertising the given host address for the host
+// clients and the network alias, or the container hostname, for the network clients
+func starterScript(options *Options, hostAddress string) string {
+	internalHost := "$(hostname)"
+	if options.NetworkAlias != nil {
+		internalHost = *options.NetworkAlias
+	}
+	internalAddress := internalHost + ":" + BrokerPort
+
+	if options.Flavor == Redpanda {
+		port := nat.Port(options.ExposedPort).Port()
+
+		return strings.Join([]string{
+			"exec /entrypoint.sh redpanda start",
+			"--mode dev-container",
+			"--smp 1",
+			fmt.Sprintf("--kafka-addr internal://0.0.0.0:%s,external://0.0.0.0:%s", BrokerPort, port),
+			fmt.Sprintf("--advertise-kafka-addr internal://%s,external://%s", internalAddress, hostAddress),
+			"--schema-registry-addr 0.0.0.0:" + SchemaRegistryPort,
+		}, " ") + "\n"
+	}
+
+	return fmt.Sprintf("export KAFKA_ADVERTISED_LISTENERS=PLAINTEXT://%s,BROKER://%s\nexec /etc/kafka/docker/run\n",
+		hostAddress,
+		internalAddress)
+}
+
+func copyStarterScript(ctx context.Context, target testcontainers.Container, options *Options) error {
+	host, err := target.Host(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the host: %w", err)
+	}
+
+	port, err := target.MappedPort(ctx, nat.Port(options.ExposedPort))
+	if err != nil {
+		return fmt.Errorf("failed to get the mapped port: %w", err)
+	}
+
+	script := starterScript(options, net.JoinHostPort(host, port.Port()))
+
+	if err := target.CopyToContainer(ctx, []byte(script), starterScriptPath, 0755); err != nil {
+		return fmt.Errorf("failed to copy the starter script: %w", err)
+	}
+
+	return nil
+}
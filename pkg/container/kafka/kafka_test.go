@@ -0,0 +1,116 @@
+package kafka_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/jfelipearaujo/testcontainers/pkg/container/kafka"
+	"github.com/jfelipearaujo/testcontainers/pkg/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithKafkaContainer(t *testing.T) {
+	t.Run("Should use the default options", func(t *testing.T) {
+		// Act
+		definition := container.NewContainerDefinition(
+			kafka.WithKafkaContainer(),
+		)
+
+		// Assert
+		assert.Equal(t, kafka.KRaftImage, definition.ContainerRequest.Image)
+		assert.Equal(t, []string{kafka.ExposedPort}, definition.ContainerRequest.ExposedPorts)
+		assert.Equal(t, []string{"sh", "-c"}, definition.ContainerRequest.Entrypoint)
+		assert.Equal(t, kafka.ClusterID, definition.ContainerRequest.Env["CLUSTER_ID"])
+		assert.Equal(t, "PLAINTEXT://0.0.0.0:9093,BROKER://0.0.0.0:9092,CONTROLLER://0.0.0.0:9094", definition.ContainerRequest.Env["KAFKA_LISTENERS"])
+		assert.Equal(t, "BROKER", definition.ContainerRequest.Env["KAFKA_INTER_BROKER_LISTENER_NAME"])
+		assert.NotNil(t, definition.ContainerRequest.WaitingFor)
+	})
+
+	t.Run("Should use the Redpanda image and expose the schema registry", func(t *testing.T) {
+		// Act
+		definition := container.NewContainerDefinition(
+			kafka.WithKafkaContainer(
+				kafka.WithFlavor(kafka.Redpanda),
+				kafka.WithSchemaRegistry(true),
+			),
+		)
+
+		// Assert
+		assert.Equal(t, kafka.RedpandaImage, definition.ContainerRequest.Image)
+		assert.Equal(t, []string{kafka.ExposedPort, kafka.SchemaRegistryPort}, definition.ContainerRequest.ExposedPorts)
+		assert.Empty(t, definition.ContainerRequest.Env)
+	})
+
+	t.Run("Should honor the options", func(t *testing.T) {
+		// Act
+		definition := container.NewContainerDefinition(
+			kafka.WithKafkaContainer(
+				kafka.WithImage("apache/kafka:3.6.0"),
+				kafka.WithExposedPort("19093"),
+				kafka.WithTopics(kafka.Topic{Name: "orders", Partitions: 3}),
+			),
+		)
+
+		// Assert
+		assert.Equal(t, "apache/kafka:3.6.0", definition.ContainerRequest.Image)
+		assert.Equal(t, []string{"19093"}, definition.ContainerRequest.ExposedPorts)
+		assert.Contains(t, definition.ContainerRequest.Env["KAFKA_LISTENERS"], "PLAINTEXT://0.0.0.0:19093")
+		assert.Len(t, definition.ContainerRequest.LifecycleHooks, 2)
+	})
+
+	t.Run("Should panic when the schema registry is enabled on the KRaft flavor", func(t *testing.T) {
+		// Act & Assert
+		assert.Panics(t, func() {
+			kafka.WithKafkaContainer(kafka.WithSchemaRegistry(true))
+		})
+	})
+}
+
+func TestWithSchemaRegistryContainer(t *testing.T) {
+	t.Run("Should connect to the broker of the network", func(t *testing.T) {
+		// Act
+		definition := container.NewContainerDefinition(
+			kafka.WithSchemaRegistryContainer(
+				kafka.WithNetwork(network.NewNetwork(network.WithAlias("broker"))),
+			),
+		)
+
+		// Assert
+		assert.Equal(t, kafka.SchemaRegistryImage, definition.ContainerRequest.Image)
+		assert.Equal(t, []string{kafka.SchemaRegistryPort}, definition.ContainerRequest.ExposedPorts)
+		assert.Equal(t, "PLAINTEXT://broker:9092", definition.ContainerRequest.Env["SCHEMA_REGISTRY_KAFKASTORE_BOOTSTRAP_SERVERS"])
+	})
+
+	t.Run("Should panic when the broker is not in a network", func(t *testing.T) {
+		// Act & Assert
+		assert.Panics(t, func() {
+			kafka.WithSchemaRegistryContainer()
+		})
+	})
+}
+
+func TestBuildInternalAddress(t *testing.T) {
+	t.Run("Should return the address of the network alias", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		ntwrkDefinition := network.NewNetwork(network.WithAlias("broker"))
+
+		// Act
+		res, err := kafka.BuildInternalAddress(ctx, nil, kafka.WithNetwork(ntwrkDefinition))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "broker:9092", res)
+	})
+
+	t.Run("Should return an error when the container is not in a network", func(t *testing.T) {
+		// Act
+		res, err := kafka.BuildInternalAddress(context.Background(), nil)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+}
@@ -0,0 +1,93 @@
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+var _ container.Module = (*Module)(nil)
+
+// Module is a type that represents the Kafka module
+type Module struct {
+	opts []KafkaOption
+}
+
+// NewModule returns the Kafka module with the given options
+//
+// Example:
+//
+//	module := kafka.NewModule(
+//		kafka.WithFlavor(kafka.Redpanda),
+//		kafka.WithTopics(kafka.Topic{Name: "orders", Partitions: 3}),
+//		kafka.WithNetwork(ntwrkDefinition),
+//	)
+func NewModule(opts ...KafkaOption) *Module {
+	return &Module{
+		opts: opts,
+	}
+}
+
+// Definition returns the options of the Kafka container definition
+func (m *Module) Definition() []container.ContainerOption {
+	return []container.ContainerOption{
+		WithKafkaContainer(m.opts...),
+	}
+}
+
+// Readiness returns the strategy used to wait for the Kafka container to be ready
+func (m *Module) Readiness() wait.Strategy {
+	return ForBroker(m.opts...).
+		WithStartupTimeout(60 * time.Second)
+}
+
+// InternalEndpoint returns the address of the broker for the containers of the same network
+func (m *Module) InternalEndpoint(ctx context.Context, container testcontainers.Container) (string, error) {
+	return BuildInternalAddress(ctx, container, m.opts...)
+}
+
+// ExternalEndpoint returns the address of the broker for the host
+func (m *Module) ExternalEndpoint(ctx context.Context, container testcontainers.Container) (string, error) {
+	return BuildExternalAddress(ctx, container, m.opts...)
+}
+
+// Reset deletes the records of all the topics, see Client.Reset
+func (m *Module) Reset(ctx context.Context, container testcontainers.Container) error {
+	brokers, err := BuildExternalAddress(ctx, container, m.opts...)
+	if err != nil {
+		return err
+	}
+
+	client, err := New(brokers)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return client.Reset(ctx)
+}
+
+// EnvForDependents returns the broker address as KAFKA_BROKERS, and the Schema Registry URL as
+// SCHEMA_REGISTRY_URL when the built-in one of the Redpanda flavor is enabled
+func (m *Module) EnvForDependents(ctx context.Context, container testcontainers.Container) (map[string]string, error) {
+	brokers, err := BuildInternalAddress(ctx, container, m.opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	env := map[string]string{
+		"KAFKA_BROKERS": brokers,
+	}
+
+	if options := buildOptions(m.opts...); options.Flavor == Redpanda && options.SchemaRegistry {
+		env["SCHEMA_REGISTRY_URL"], err = BuildInternalSchemaRegistryURL(ctx, container, m.opts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return env, nil
+}
@@ -0,0 +1,47 @@
+package kafka_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/jfelipearaujo/testcontainers/pkg/container/kafka"
+	"github.com/jfelipearaujo/testcontainers/pkg/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModule(t *testing.T) {
+	t.Run("Should honor the options in the definition", func(t *testing.T) {
+		// Arrange
+		module := kafka.NewModule(
+			kafka.WithFlavor(kafka.Redpanda),
+		)
+
+		// Act
+		definition := container.NewModuleDefinition(module)
+
+		// Assert
+		assert.Equal(t, kafka.RedpandaImage, definition.ContainerRequest.Image)
+		assert.NotNil(t, definition.ContainerRequest.WaitingFor)
+	})
+
+	t.Run("Should return the environment of the dependents", func(t *testing.T) {
+		// Arrange
+		module := kafka.NewModule(
+			kafka.WithFlavor(kafka.Redpanda),
+			kafka.WithSchemaRegistry(true),
+			kafka.WithNetwork(network.NewNetwork(network.WithAlias("broker"))),
+		)
+
+		// Act
+		env, err := module.EnvForDependents(context.Background(), nil)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"KAFKA_BROKERS":       "broker:9092",
+			"SCHEMA_REGISTRY_URL": "http://broker:8081",
+		}, env)
+	})
+}
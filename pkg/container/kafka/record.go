@@ -0,0 +1,139 @@
+package kafka
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/internal/subset"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// Record is a type that represents a produced or a received Kafka record
+//
+// Partition, Offset and Timestamp are only set on the received records
+type Record struct {
+	Key       string
+	Value     string
+	Headers   map[string]string
+	Partition int32
+	Offset    int64
+	Timestamp time.Time
+}
+
+func parseRecord(record *kgo.Record) Record {
+	output := Record{
+		Key:       string(record.Key),
+		Value:     string(record.Value),
+		Headers:   make(map[string]string, len(record.Headers)),
+		Partition: record.Partition,
+		Offset:    record.Offset,
+		Timestamp: record.Timestamp,
+	}
+
+	for _, header := range record.Headers {
+		output.Headers[header.Key] = string(header.Value)
+	}
+
+	return output
+}
+
+func (r Record) kafkaRecord(topic string) *kgo.Record {
+	record := &kgo.Record{
+		Topic: topic,
+		Value: []byte(r.Value),
+	}
+
+	if r.Key != "" {
+		record.Key = []byte(r.Key)
+	}
+
+	for key, value := range r.Headers {
+		record.Headers = append(record.Headers, kgo.RecordHeader{
+			Key:   key,
+			Value: []byte(value),
+		})
+	}
+
+	return record
+}
+
+// Matcher is a type that represents a record matcher, returning the reasons why the record does not match
+type Matcher func(record Record) []string
+
+// MatchJSON is a Matcher that checks that the value of the record contains the expected JSON, see subset.Diff
+//
+// Example:
+//
+//	kafka.MatchJSON(`{"type": "order_created", "order": {"id": "123"}}`, "createdAt")
+func MatchJSON(expected string, ignore ...string) Matcher {
+	return func(record Record) []string {
+		diffs, err := subset.DiffJSON([]byte(expected), []byte(record.Value), ignore...)
+		if err != nil {
+			return []string{err.Error()}
+		}
+		return diffs
+	}
+}
+
+// MatchKey is a Matcher that checks the key of the record
+//
+// Example:
+//
+//	kafka.MatchKey("123")
+func MatchKey(expected string) Matcher {
+	return func(record Record) []string {
+		if record.Key != expected {
+			return []string{fmt.Sprintf("key: expected %q, got %q", expected, record.Key)}
+		}
+		return nil
+	}
+}
+
+// MatchHeaders is a Matcher that checks the headers of the record
+//
+// Example:
+//
+//	kafka.MatchHeaders(map[string]string{"eventType": "order_created"})
+func MatchHeaders(expected map[string]string) Matcher {
+	return func(record Record) []string {
+		var diffs []string
+		for name, value := range expected {
+			actual, ok := record.Headers[name]
+			if !ok {
+				diffs = append(diffs, fmt.Sprintf("header %s: expected %q, got nothing", name, value))
+				continue
+			}
+			if actual != value {
+				diffs = append(diffs, fmt.Sprintf("header %s: expected %q, got %q", name, value, actual))
+			}
+		}
+		return diffs
+	}
+}
+
+// Match returns the reasons why the record does not match all the given matchers
+func Match(record Record, matchers ...Matcher) []string {
+	var diffs []string
+	for _, matcher := range matchers {
+		diffs = append(diffs, matcher(record)...)
+	}
+	return diffs
+}
+
+// MismatchError is the error returned when the expected records are not received before the timeout
+type MismatchError struct {
+	Topic    string
+	Expected int
+	Received int
+	Closest  [][]string
+}
+
+func (e *MismatchError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "expected %d matching records on the topic '%s', but got %d", e.Expected, e.Topic, e.Received)
+	for i, diffs := range e.Closest {
+		fmt.Fprintf(&sb, "\n  closest record %d:%s", i+1, subset.Format(diffs))
+	}
+	return sb.String()
+}
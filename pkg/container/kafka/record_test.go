@@ -0,0 +1,71 @@
+package kafka_test
+
+import (
+	"testing"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container/kafka"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch(t *testing.T) {
+	record := kafka.Record{
+		Key:     "123",
+		Value:   `{"type": "order_created", "order": {"id": "123", "total": 10}}`,
+		Headers: map[string]string{"eventType": "order_created"},
+	}
+
+	t.Run("Should match the key, the JSON subset and the headers", func(t *testing.T) {
+		// Act
+		diffs := kafka.Match(record,
+			kafka.MatchKey("123"),
+			kafka.MatchJSON(`{"order": {"id": "123"}}`),
+			kafka.MatchHeaders(map[string]string{"eventType": "order_created"}),
+		)
+
+		// Assert
+		assert.Empty(t, diffs)
+	})
+
+	t.Run("Should report the differences", func(t *testing.T) {
+		// Act
+		diffs := kafka.Match(record,
+			kafka.MatchKey("456"),
+			kafka.MatchJSON(`{"order": {"id": "456"}}`),
+			kafka.MatchHeaders(map[string]string{"source": "api"}),
+		)
+
+		// Assert
+		assert.Equal(t, []string{
+			`key: expected "456", got "123"`,
+			`$.order.id: expected "456", got "123"`,
+			`header source: expected "api", got nothing`,
+		}, diffs)
+	})
+
+	t.Run("Should not match a value that is not JSON", func(t *testing.T) {
+		// Act
+		diffs := kafka.Match(kafka.Record{Value: "Hello World!"}, kafka.MatchJSON(`{}`))
+
+		// Assert
+		assert.Len(t, diffs, 1)
+	})
+}
+
+func TestMismatchError(t *testing.T) {
+	t.Run("Should report the closest records", func(t *testing.T) {
+		// Arrange
+		err := &kafka.MismatchError{
+			Topic:    "orders",
+			Expected: 1,
+			Closest:  [][]string{{`key: expected "456", got "123"`}},
+		}
+
+		// Act
+		res := err.Error()
+
+		// Assert
+		assert.Contains(t, res, "expected 1 matching records on the topic 'orders', but got 0")
+		assert.Contains(t, res, "closest record 1:")
+		assert.Contains(t, res, `key: expected "456", got "123"`)
+	})
+}
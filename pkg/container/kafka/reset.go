@@ -0,0 +1,103 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/twmb/franz-go/pkg/kadm"
+)
+
+// Reset deletes the records of all the non-internal topics, keeping the topics and their configuration
+//
+// The compacted topics, like the _schemas topic of the Schema Registry, are skipped, the broker refusing
+// to delete their records.
+//
+// The committed offsets of the consumer groups are kept, so they point to the end of the emptied partitions
+// and the running consumers are not affected.
+//
+// Example:
+//
+//	err := client.Reset(ctx)
+func (c *Client) Reset(ctx context.Context) error {
+	admin := kadm.NewClient(c.Kafka)
+
+	topics, err := admin.ListTopics(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list the topics: %w", err)
+	}
+
+	names, err := deletableTopics(ctx, admin, topics.Names())
+	if err != nil {
+		return err
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	ends, err := admin.ListEndOffsets(ctx, names...)
+	if err != nil {
+		return fmt.Errorf("failed to list the end offsets: %w", err)
+	}
+	if err := ends.Error(); err != nil {
+		return fmt.Errorf("failed to list the end offsets: %w", err)
+	}
+
+	deleted, err := admin.DeleteRecords(ctx, ends.Offsets())
+	if err != nil {
+		return fmt.Errorf("failed to delete the records: %w", err)
+	}
+	if err := deleted.Error(); err != nil {
+		return fmt.Errorf("failed to delete the records: %w", err)
+	}
+
+	return nil
+}
+
+// deletableTopics returns the given topics whose cleanup policy allows deleting the records
+func deletableTopics(ctx context.Context, admin *kadm.Client, topics []string) ([]string, error) {
+	if len(topics) == 0 {
+		return nil, nil
+	}
+
+	configs, err := admin.DescribeTopicConfigs(ctx, topics...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe the topics: %w", err)
+	}
+
+	var deletable []string
+	for _, config := range configs {
+		if config.Err != nil {
+			return nil, fmt.Errorf("failed to describe the topic '%s': %w", config.Name, config.Err)
+		}
+
+		policy := "delete"
+		for _, entry := range config.Configs {
+			if entry.Key == "cleanup.policy" && entry.Value != nil {
+				policy = *entry.Value
+			}
+		}
+
+		if strings.Contains(policy, "delete") {
+			deletable = append(deletable, config.Name)
+		}
+	}
+
+	return deletable, nil
+}
+
+// ResetHook returns a reset hook that calls Client.Reset, to be registered on a shared group of containers
+//
+// Example:
+//
+//	group := container.BuildGroupContainer(
+//		container.WithDockerContainer(kafkaContainer),
+//		container.WithResetHook(kafka.ResetHook(client)),
+//	)
+func ResetHook(client *Client) container.ResetFunc {
+	return func(ctx context.Context) error {
+		return client.Reset(ctx)
+	}
+}
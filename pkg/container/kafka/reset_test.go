@@ -0,0 +1,71 @@
+package kafka_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kfake"
+)
+
+// newFakeCluster returns an in-memory single broker cluster and a client of it
+func newFakeCluster(t *testing.T) (*kfake.Cluster, *kafka.Client) {
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1))
+	require.NoError(t, err)
+	t.Cleanup(cluster.Close)
+
+	client, err := kafka.New(cluster.ListenAddrs()[0])
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	return cluster, client
+}
+
+// newFakeClient returns a client of an in-memory single broker cluster
+func newFakeClient(t *testing.T) *kafka.Client {
+	_, client := newFakeCluster(t)
+	return client
+}
+
+func TestReset(t *testing.T) {
+	t.Run("Should delete the records and skip the compacted topics", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		client := newFakeClient(t)
+
+		require.NoError(t, client.CreateTopics(ctx,
+			kafka.Topic{Name: "orders", Partitions: 1},
+			kafka.Topic{Name: "_schemas", Partitions: 1, Config: map[string]string{"cleanup.policy": "compact"}},
+		))
+		require.NoError(t, client.Produce(ctx, "orders", kafka.Record{Value: "1"}, kafka.Record{Value: "2"}))
+		require.NoError(t, client.Produce(ctx, "_schemas", kafka.Record{Key: "schema", Value: "{}"}))
+
+		// Act
+		err := client.Reset(ctx)
+
+		// Assert
+		require.NoError(t, err)
+
+		starts, err := kadm.NewClient(client.Kafka).ListStartOffsets(ctx, "orders", "_schemas")
+		require.NoError(t, err)
+
+		orders, _ := starts.Lookup("orders", 0)
+		schemas, _ := starts.Lookup("_schemas", 0)
+		assert.Equal(t, int64(2), orders.Offset)
+		assert.Equal(t, int64(0), schemas.Offset)
+	})
+
+	t.Run("Should not fail without topics", func(t *testing.T) {
+		// Arrange
+		client := newFakeClient(t)
+
+		// Act
+		err := client.Reset(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+	})
+}
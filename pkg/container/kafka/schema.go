@@ -0,0 +1,184 @@
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	SchemaRegistryImage string = "confluentinc/cp-schema-registry:7.6.1"
+	SchemaRegistryPort  string = "8081"
+
+	schemaRegistryContentType string = "application/vnd.schemaregistry.v1+json"
+)
+
+// SchemaType is a type that represents the type of a registered schema
+type SchemaType string
+
+const (
+	Avro     SchemaType = "AVRO"
+	Protobuf SchemaType = "PROTOBUF"
+	JSON     SchemaType = "JSON"
+)
+
+// Return a new container definition for a Schema Registry container backed by the Kafka container with the given
+// options, to be used with the KRaft flavor, the Redpanda flavor having a built-in one, see WithSchemaRegistry
+//
+//	DockerImage: "confluentinc/cp-schema-registry:7.6.1"
+//	Exposed ports: "8081"
+//	WaitingForPath: "/subjects"
+//	StartupTimeout: "60 seconds"
+//
+// The Kafka container must be in a network, the same KafkaOptions being given to both definitions.
+//
+// Example:
+//
+//	definition := container.NewContainerDefinition(
+//		container.WithNetwork("schema-registry", network),
+//		kafka.WithSchemaRegistryContainer(
+//			kafka.WithNetwork(ntwrkDefinition),
+//		),
+//	)
+func WithSchemaRegistryContainer(opts ...KafkaOption) container.ContainerOption {
+	options := buildOptions(opts...)
+
+	if options.NetworkAlias == nil {
+		panic(fmt.Errorf("the schema registry needs the Kafka container to be in a network, use WithNetwork"))
+	}
+
+	return func(container *container.Container) {
+		container.ContainerRequest.Image = SchemaRegistryImage
+		container.ContainerRequest.ExposedPorts = []string{
+			SchemaRegistryPort,
+		}
+		container.ContainerRequest.Env = map[string]string{
+			"SCHEMA_REGISTRY_HOST_NAME":                    "schema-registry",
+			"SCHEMA_REGISTRY_LISTENERS":                    "http://0.0.0.0:" + SchemaRegistryPort,
+			"SCHEMA_REGISTRY_KAFKASTORE_BOOTSTRAP_SERVERS": "PLAINTEXT://" + net.JoinHostPort(*options.NetworkAlias, BrokerPort),
+		}
+		container.ContainerRequest.WaitingFor = wait.ForHTTP("/subjects").
+			WithPort(nat.Port(SchemaRegistryPort + "/tcp")).
+			WithStartupTimeout(60 * time.Second)
+	}
+}
+
+// Return the URL of the Schema Registry of the given Redpanda container when the container is in a network
+//
+//	Example: "http://network_alias:8081"
+func BuildInternalSchemaRegistryURL(ctx context.Context, container testcontainers.Container, opts ...KafkaOption) (string, error) {
+	options := buildOptions(opts...)
+
+	if options.NetworkAlias == nil {
+		return "", fmt.Errorf("the container is not in a network")
+	}
+
+	return "http://" + net.JoinHostPort(*options.NetworkAlias, SchemaRegistryPort), nil
+}
+
+// Return the URL of the Schema Registry of the given Redpanda or Schema Registry container when the container
+// is NOT in a network
+//
+//	Example: "http://localhost:49154"
+func BuildExternalSchemaRegistryURL(ctx context.Context, container testcontainers.Container) (string, error) {
+	host, err := container.Host(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the host: %w", err)
+	}
+
+	port, err := container.MappedPort(ctx, nat.Port(SchemaRegistryPort))
+	if err != nil {
+		return "", fmt.Errorf("failed to get the mapped port: %w", err)
+	}
+
+	return "http://" + net.JoinHostPort(host, port.Port()), nil
+}
+
+// RegisterSchema registers the schema for the given subject and returns its id, registering the same schema
+// again returning the same id
+//
+// Example:
+//
+//	id, err := kafka.RegisterSchema(ctx, schemaRegistryURL, "orders-value", kafka.Avro, schema)
+func RegisterSchema(ctx context.Context, schemaRegistryURL string, subject string, schemaType SchemaType, schema string) (int, error) {
+	body := map[string]string{
+		"schema": schema,
+	}
+	// the registry rejects the explicit type of the Avro schemas on the older versions
+	if schemaType != "" && schemaType != Avro {
+		body["schemaType"] = string(schemaType)
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return 0, err
+	}
+
+	endpoint := strings.TrimSuffix(schemaRegistryURL, "/") + "/subjects/" + url.PathEscape(subject) + "/versions"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", schemaRegistryContentType)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to register the schema of the subject '%s': %w", subject, err)
+	}
+	defer resp.Body.Close()
+
+	var output struct {
+		ID      int    `json:"id"`
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&output); err != nil && resp.StatusCode == http.StatusOK {
+		return 0, fmt.Errorf("failed to parse the registered schema of the subject '%s': %w", subject, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("failed to register the schema of the subject '%s': unexpected status code %d: %s", subject, resp.StatusCode, output.Message)
+	}
+
+	return output.ID, nil
+}
+
+// RegisterSchemaFile registers the schema of the given file, the type being read from its extension:
+// ".avsc" for Avro, ".proto" for Protobuf and ".json" for JSON Schema
+//
+// Example:
+//
+//	id, err := kafka.RegisterSchemaFile(ctx, schemaRegistryURL, "orders-value", "./testdata/order.avsc")
+func RegisterSchemaFile(ctx context.Context, schemaRegistryURL string, subject string, file string) (int, error) {
+	var schemaType SchemaType
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".avsc":
+		schemaType = Avro
+	case ".proto":
+		schemaType = Protobuf
+	case ".json":
+		schemaType = JSON
+	default:
+		return 0, fmt.Errorf("unsupported schema file '%s', the extension must be .avsc, .proto or .json", file)
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read the schema '%s': %w", file, err)
+	}
+
+	return RegisterSchema(ctx, schemaRegistryURL, subject, schemaType, string(content))
+}
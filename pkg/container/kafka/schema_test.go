@@ -0,0 +1,86 @@
+package kafka_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterSchema(t *testing.T) {
+	t.Run("Should register the schema and return its id", func(t *testing.T) {
+		// Arrange
+		var path, contentType string
+		var body map[string]string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			contentType = r.Header.Get("Content-Type")
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			_, _ = w.Write([]byte(`{"id": 7}`))
+		}))
+		defer server.Close()
+
+		// Act
+		id, err := kafka.RegisterSchema(context.Background(), server.URL, "orders-value", kafka.JSON, `{"type": "object"}`)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 7, id)
+		assert.Equal(t, "/subjects/orders-value/versions", path)
+		assert.Equal(t, "application/vnd.schemaregistry.v1+json", contentType)
+		assert.Equal(t, map[string]string{"schema": `{"type": "object"}`, "schemaType": "JSON"}, body)
+	})
+
+	t.Run("Should return the message of the registry on error", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"error_code": 42201, "message": "Invalid schema"}`))
+		}))
+		defer server.Close()
+
+		// Act
+		id, err := kafka.RegisterSchema(context.Background(), server.URL, "orders-value", kafka.Avro, `{`)
+
+		// Assert
+		assert.ErrorContains(t, err, "Invalid schema")
+		assert.Zero(t, id)
+	})
+}
+
+func TestRegisterSchemaFile(t *testing.T) {
+	t.Run("Should read the type from the extension", func(t *testing.T) {
+		// Arrange
+		var body map[string]string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			_, _ = w.Write([]byte(`{"id": 1}`))
+		}))
+		defer server.Close()
+
+		file := filepath.Join(t.TempDir(), "order.proto")
+		require.NoError(t, os.WriteFile(file, []byte(`syntax = "proto3";`), 0644))
+
+		// Act
+		_, err := kafka.RegisterSchemaFile(context.Background(), server.URL, "orders-value", file)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "PROTOBUF", body["schemaType"])
+	})
+
+	t.Run("Should return an error for an unknown extension", func(t *testing.T) {
+		// Act
+		_, err := kafka.RegisterSchemaFile(context.Background(), "http://localhost", "orders-value", "order.txt")
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
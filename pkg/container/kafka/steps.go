@@ -0,0 +1,194 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cucumber/godog"
+)
+
+// Timeout is the default time to wait for the expected records
+const Timeout time.Duration = 10 * time.Second
+
+// Steps is a type that represents the topic steps of a Kafka container
+type Steps struct {
+	Client         func(ctx context.Context) (*Client, error)
+	SchemaRegistry func(ctx context.Context) (string, error)
+	Timeout        time.Duration
+}
+
+// StepsOption is a type that represents a Steps option
+type StepsOption func(*Steps)
+
+// WithTimeout is a StepsOption that sets the time to wait for the expected records
+//
+// Default: 10 seconds
+func WithTimeout(timeout time.Duration) StepsOption {
+	return func(steps *Steps) {
+		steps.Timeout = timeout
+	}
+}
+
+// WithSchemaRegistryURL is a StepsOption that sets the function returning the Schema Registry URL of the current
+// scenario, needed by the schema steps
+//
+// Default: nil
+func WithSchemaRegistryURL(schemaRegistry func(ctx context.Context) (string, error)) StepsOption {
+	return func(steps *Steps) {
+		steps.SchemaRegistry = schemaRegistry
+	}
+}
+
+// RegisterSteps registers the topic steps on the given scenario context
+//
+// The client function must return the client of the current scenario. The registered steps are:
+//
+//	Given a message is produced to the topic "orders":
+//	Given a message with the key "123" is produced to the topic "orders":
+//	Given the following messages are produced to the topic "orders":
+//	Given the schema "./testdata/order.avsc" is registered for the subject "orders-value"
+//	Then the topic "orders" should receive 1 message
+//	Then the topic "orders" should receive 1 message matching:
+//	Then the topic "orders" should receive 1 message with the key "123"
+//	Then the topic "orders" should receive 1 message with headers:
+//
+// The receive steps expect at least the given number of messages, the messages produced beyond it not failing the step.
+// The single messages are given as a doc string. The messages table has a "key" and a "value" column,
+// the other columns being the headers. The messages are matched as a JSON subset of the values,
+// and the headers are given as a "| name | value |" table.
+func RegisterSteps(ctx *godog.ScenarioContext, client func(ctx context.Context) (*Client, error), opts ...StepsOption) *Steps {
+	steps := &Steps{
+		Client:  client,
+		Timeout: Timeout,
+	}
+
+	for _, opt := range opts {
+		opt(steps)
+	}
+
+	ctx.Step(`^a message is produced to the topic "([^"]*)":$`, steps.aMessageIsProduced)
+	ctx.Step(`^a message with the key "([^"]*)" is produced to the topic "([^"]*)":$`, steps.aMessageWithTheKeyIsProduced)
+	ctx.Step(`^the following messages are produced to the topic "([^"]*)":$`, steps.theFollowingMessagesAreProduced)
+	ctx.Step(`^the schema "([^"]*)" is registered for the subject "([^"]*)"$`, steps.theSchemaIsRegistered)
+	ctx.Step(`^the topic "([^"]*)" should receive (\d+) messages?$`, steps.theTopicShouldReceiveMessages)
+	ctx.Step(`^the topic "([^"]*)" should receive (\d+) messages? matching:$`, steps.theTopicShouldReceiveMessagesMatching)
+	ctx.Step(`^the topic "([^"]*)" should receive (\d+) messages? with the key "([^"]*)"$`, steps.theTopicShouldReceiveMessagesWithTheKey)
+	ctx.Step(`^the topic "([^"]*)" should receive (\d+) messages? with headers:$`, steps.theTopicShouldReceiveMessagesWithHeaders)
+
+	return steps
+}
+
+func (steps *Steps) aMessageIsProduced(ctx context.Context, topic string, value *godog.DocString) (context.Context, error) {
+	return ctx, steps.produce(ctx, topic, Record{Value: value.Content})
+}
+
+func (steps *Steps) aMessageWithTheKeyIsProduced(ctx context.Context, key string, topic string, value *godog.DocString) (context.Context, error) {
+	return ctx, steps.produce(ctx, topic, Record{Key: key, Value: value.Content})
+}
+
+func (steps *Steps) theFollowingMessagesAreProduced(ctx context.Context, topic string, table *godog.Table) (context.Context, error) {
+	records, err := tableRecords(table)
+	if err != nil {
+		return ctx, err
+	}
+
+	return ctx, steps.produce(ctx, topic, records...)
+}
+
+func (steps *Steps) theSchemaIsRegistered(ctx context.Context, file string, subject string) (context.Context, error) {
+	if steps.SchemaRegistry == nil {
+		return ctx, fmt.Errorf("the schema registry is not set, use WithSchemaRegistryURL")
+	}
+
+	schemaRegistryURL, err := steps.SchemaRegistry(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	_, err = RegisterSchemaFile(ctx, schemaRegistryURL, subject, file)
+	return ctx, err
+}
+
+func (steps *Steps) theTopicShouldReceiveMessages(ctx context.Context, topic string, n int) (context.Context, error) {
+	return ctx, steps.await(ctx, topic, n)
+}
+
+func (steps *Steps) theTopicShouldReceiveMessagesMatching(ctx context.Context, topic string, n int, value *godog.DocString) (context.Context, error) {
+	return ctx, steps.await(ctx, topic, n, MatchJSON(value.Content))
+}
+
+func (steps *Steps) theTopicShouldReceiveMessagesWithTheKey(ctx context.Context, topic string, n int, key string) (context.Context, error) {
+	return ctx, steps.await(ctx, topic, n, MatchKey(key))
+}
+
+func (steps *Steps) theTopicShouldReceiveMessagesWithHeaders(ctx context.Context, topic string, n int, table *godog.Table) (context.Context, error) {
+	headers := make(map[string]string, len(table.Rows))
+	for _, row := range table.Rows {
+		if len(row.Cells) != 2 {
+			return ctx, fmt.Errorf("the headers table must have 2 columns: name and value")
+		}
+		headers[row.Cells[0].Value] = row.Cells[1].Value
+	}
+
+	return ctx, steps.await(ctx, topic, n, MatchHeaders(headers))
+}
+
+func (steps *Steps) produce(ctx context.Context, topic string, records ...Record) error {
+	client, err := steps.Client(ctx)
+	if err != nil {
+		return err
+	}
+
+	return client.Produce(ctx, topic, records...)
+}
+
+func (steps *Steps) await(ctx context.Context, topic string, n int, matchers ...Matcher) error {
+	client, err := steps.Client(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.AwaitRecords(ctx, topic, n, steps.Timeout, matchers...)
+	return err
+}
+
+// tableRecords returns the records of a table with a "key" and a "value" column, the other columns being the headers
+func tableRecords(table *godog.Table) ([]Record, error) {
+	if len(table.Rows) == 0 {
+		return nil, fmt.Errorf("the messages table must have a header row")
+	}
+
+	header := table.Rows[0].Cells
+	hasValue := false
+	for _, cell := range header {
+		if cell.Value == "value" {
+			hasValue = true
+		}
+	}
+	if !hasValue {
+		return nil, fmt.Errorf("the messages table must have a value column")
+	}
+
+	records := make([]Record, 0, len(table.Rows)-1)
+	for _, row := range table.Rows[1:] {
+		record := Record{
+			Headers: make(map[string]string),
+		}
+
+		for i, cell := range row.Cells {
+			switch name := header[i].Value; name {
+			case "key":
+				record.Key = cell.Value
+			case "value":
+				record.Value = cell.Value
+			default:
+				record.Headers[name] = cell.Value
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
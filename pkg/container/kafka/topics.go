@@ -0,0 +1,78 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kerr"
+)
+
+// Topic is a type that represents a topic to be created
+//
+// Partitions defaults to the broker default when zero, the replication factor being the broker default as well
+type Topic struct {
+	Name       string
+	Partitions int32
+	Config     map[string]string
+}
+
+// CreateTopics creates the given topics, the existing topics being left untouched, so it can be called again
+//
+// Example:
+//
+//	err := client.CreateTopics(ctx,
+//		kafka.Topic{Name: "orders", Partitions: 3},
+//		kafka.Topic{Name: "orders-dlq", Config: map[string]string{"retention.ms": "60000"}},
+//	)
+func (c *Client) CreateTopics(ctx context.Context, topics ...Topic) error {
+	admin := kadm.NewClient(c.Kafka)
+
+	for _, topic := range topics {
+		partitions := topic.Partitions
+		if partitions == 0 {
+			partitions = -1
+		}
+
+		var configs map[string]*string
+		if len(topic.Config) > 0 {
+			configs = make(map[string]*string, len(topic.Config))
+			for key, value := range topic.Config {
+				configs[key] = kadm.StringPtr(value)
+			}
+		}
+
+		_, err := admin.CreateTopic(ctx, partitions, -1, configs, topic.Name)
+		if err != nil && !errors.Is(err, kerr.TopicAlreadyExists) {
+			return fmt.Errorf("failed to create the topic '%s': %w", topic.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func withTopics(c *container.Container, opts ...KafkaOption) {
+	options := buildOptions(opts...)
+
+	c.ContainerRequest.LifecycleHooks = append(c.ContainerRequest.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+		PostReadies: []testcontainers.ContainerHook{
+			func(ctx context.Context, target testcontainers.Container) error {
+				brokers, err := BuildExternalAddress(ctx, target, opts...)
+				if err != nil {
+					return err
+				}
+
+				client, err := New(brokers)
+				if err != nil {
+					return err
+				}
+				defer client.Close()
+
+				return client.CreateTopics(ctx, options.Topics...)
+			},
+		},
+	})
+}
@@ -0,0 +1,30 @@
+package kafka
+
+import (
+	"context"
+	"net"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// BrokerStrategy is a wait strategy that waits until the Kafka container answers a metadata request
+type BrokerStrategy = container.PingStrategy
+
+// ForBroker returns a wait strategy that connects to the listener of the host clients and pings the broker
+//
+//	Default startup timeout: 60 seconds
+//	Default poll interval: 500 milliseconds
+func ForBroker(opts ...KafkaOption) *BrokerStrategy {
+	options := buildOptions(opts...)
+
+	return container.ForPing(options.ExposedPort, func(ctx context.Context, host string, port string) error {
+		client, err := kgo.NewClient(kgo.SeedBrokers(net.JoinHostPort(host, port)))
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		return client.Ping(ctx)
+	})
+}
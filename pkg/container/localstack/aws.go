@@ -0,0 +1,70 @@
+package localstack
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	AccountID       string = "000000000000"
+	AccessKeyID     string = "test"
+	SecretAccessKey string = "test"
+)
+
+// AWSConfig returns an aws.Config that targets the given LocalStack container, with static test credentials
+// and the region set by WithDefaultRegion
+//
+// The process environment variables and the shared AWS files are never read, so scenarios can run in parallel.
+// S3 clients must be created with NewS3Client to use path-style addressing.
+//
+// Example:
+//
+//	cfg, err := localstack.AWSConfig(ctx, localStackContainer)
+//	if err != nil {
+//		return ctx, err
+//	}
+//
+//	client := sqs.NewFromConfig(cfg)
+func AWSConfig(ctx context.Context, container testcontainers.Container, opts ...LocalStackOption) (aws.Config, error) {
+	endpoint, err := BuildEndpoint(ctx, container, opts...)
+	if err != nil {
+		return aws.Config{}, err
+	}
+
+	return NewAWSConfig(endpoint, opts...), nil
+}
+
+// NewAWSConfig returns an aws.Config that targets the given LocalStack endpoint, see AWSConfig
+//
+// Example:
+//
+//	cfg := localstack.NewAWSConfig("http://localhost:4566")
+func NewAWSConfig(endpoint string, opts ...LocalStackOption) aws.Config {
+	options := buildOptions(opts...)
+
+	return aws.Config{
+		Region:       options.DefaultRegion,
+		Credentials:  credentials.NewStaticCredentialsProvider(AccessKeyID, SecretAccessKey, ""),
+		BaseEndpoint: aws.String(endpoint),
+	}
+}
+
+// NewS3Client returns an S3 client for the given config that uses path-style addressing, as LocalStack does not
+// resolve the bucket subdomains of every endpoint
+//
+// Example:
+//
+//	client := localstack.NewS3Client(cfg)
+func NewS3Client(cfg aws.Config, optFns ...func(*s3.Options)) *s3.Client {
+	optFns = append([]func(*s3.Options){
+		func(options *s3.Options) {
+			options.UsePathStyle = true
+		},
+	}, optFns...)
+
+	return s3.NewFromConfig(cfg, optFns...)
+}
@@ -0,0 +1,39 @@
+package localstack_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container/localstack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAWSConfig(t *testing.T) {
+	t.Run("Should use the endpoint, the region and the static credentials", func(t *testing.T) {
+		// Act
+		cfg := localstack.NewAWSConfig("http://localhost:4566",
+			localstack.WithDefaultRegion("eu-west-1"),
+		)
+
+		// Assert
+		assert.Equal(t, "eu-west-1", cfg.Region)
+		require.NotNil(t, cfg.BaseEndpoint)
+		assert.Equal(t, "http://localhost:4566", *cfg.BaseEndpoint)
+
+		credentials, err := cfg.Credentials.Retrieve(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, localstack.AccessKeyID, credentials.AccessKeyID)
+		assert.Equal(t, localstack.SecretAccessKey, credentials.SecretAccessKey)
+	})
+}
+
+func TestNewS3Client(t *testing.T) {
+	t.Run("Should use path-style addressing", func(t *testing.T) {
+		// Act
+		client := localstack.NewS3Client(localstack.NewAWSConfig("http://localhost:4566"))
+
+		// Assert
+		assert.True(t, client.Options().UsePathStyle)
+	})
+}
@@ -0,0 +1,195 @@
+package localstack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamotypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	// maxBatchWriteItems is the maximum number of items of a BatchWriteItem request
+	maxBatchWriteItems int = 25
+
+	// maxUnprocessedRetries is the number of times the unprocessed items of a batch are written again
+	maxUnprocessedRetries int = 6
+
+	// maxUnprocessedBackoff caps the wait before writing again the unprocessed items
+	maxUnprocessedBackoff time.Duration = 2 * time.Second
+)
+
+// unprocessedBackoff is the first wait before writing again the unprocessed items, doubled on every retry
+var unprocessedBackoff = 50 * time.Millisecond
+
+// ReadTableSchema reads a table schema written in the JSON format of "aws dynamodb create-table --cli-input-json",
+// the billing mode defaults to PAY_PER_REQUEST when no provisioned throughput is set
+//
+//	{
+//		"TableName": "orders",
+//		"KeySchema": [{"AttributeName": "id", "KeyType": "HASH"}],
+//		"AttributeDefinitions": [{"AttributeName": "id", "AttributeType": "S"}]
+//	}
+func ReadTableSchema(file string) (*dynamodb.CreateTableInput, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the table schema '%s': %w", file, err)
+	}
+
+	var input dynamodb.CreateTableInput
+	if err := json.Unmarshal(content, &input); err != nil {
+		return nil, fmt.Errorf("failed to parse the table schema '%s': %w", file, err)
+	}
+
+	if aws.ToString(input.TableName) == "" {
+		return nil, fmt.Errorf("the table schema '%s' has no TableName", file)
+	}
+
+	if input.BillingMode == "" && input.ProvisionedThroughput == nil {
+		input.BillingMode = dynamotypes.BillingModePayPerRequest
+	}
+
+	return &input, nil
+}
+
+// CreateTables creates the tables of the given schema files, see ReadTableSchema
+//
+// Example:
+//
+//	err := localstack.CreateTables(ctx, cfg, "./testdata/orders.table.json")
+func CreateTables(ctx context.Context, cfg aws.Config, files ...string) error {
+	client := dynamodb.NewFromConfig(cfg)
+
+	for _, file := range files {
+		input, err := ReadTableSchema(file)
+		if err != nil {
+			return err
+		}
+
+		if _, err := createTable(ctx, client, input); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadItems reads the items of a JSON file containing an array of objects
+//
+//	[
+//		{"id": "1", "status": "created", "total": 10}
+//	]
+func ReadItems(file string) ([]map[string]dynamotypes.AttributeValue, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the items '%s': %w", file, err)
+	}
+
+	var values []map[string]any
+	if err := json.Unmarshal(content, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse the items '%s': %w", file, err)
+	}
+
+	items := make([]map[string]dynamotypes.AttributeValue, len(values))
+	for i, value := range values {
+		items[i], err = attributevalue.MarshalMap(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the item %d of '%s': %w", i+1, file, err)
+		}
+	}
+
+	return items, nil
+}
+
+// LoadItems writes the items of the given JSON file into the table, see ReadItems
+//
+// The items left unprocessed by a throttled table are written again with an exponential backoff, up to 6 times.
+//
+// Example:
+//
+//	err := localstack.LoadItems(ctx, cfg, "orders", "./testdata/orders.json")
+func LoadItems(ctx context.Context, cfg aws.Config, table string, file string) error {
+	items, err := ReadItems(file)
+	if err != nil {
+		return err
+	}
+
+	client := dynamodb.NewFromConfig(cfg)
+
+	for start := 0; start < len(items); start += maxBatchWriteItems {
+		requests := make([]dynamotypes.WriteRequest, 0, maxBatchWriteItems)
+		for _, item := range items[start:min(start+maxBatchWriteItems, len(items))] {
+			requests = append(requests, dynamotypes.WriteRequest{
+				PutRequest: &dynamotypes.PutRequest{Item: item},
+			})
+		}
+
+		if err := writeBatch(ctx, client, table, requests); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeBatch writes the requests in a single batch, then writes again the unprocessed ones after a growing wait
+func writeBatch(ctx context.Context, client *dynamodb.Client, table string, requests []dynamotypes.WriteRequest) error {
+	pending := map[string][]dynamotypes.WriteRequest{table: requests}
+	backoff := unprocessedBackoff
+
+	for retry := 0; ; retry++ {
+		output, err := client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+			RequestItems: pending,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write the items of '%s': %w", table, err)
+		}
+
+		pending = output.UnprocessedItems
+		if len(pending) == 0 {
+			return nil
+		}
+
+		if retry == maxUnprocessedRetries {
+			return fmt.Errorf("failed to write the items of '%s': %d still unprocessed after %d retries",
+				table, len(pending[table]), maxUnprocessedRetries)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to write the items of '%s': %w", table, ctx.Err())
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, maxUnprocessedBackoff)
+	}
+}
+
+// ScanItems returns all the items of the table, decoded as JSON-like values
+func ScanItems(ctx context.Context, cfg aws.Config, table string) ([]map[string]any, error) {
+	client := dynamodb.NewFromConfig(cfg)
+	paginator := dynamodb.NewScanPaginator(client, &dynamodb.ScanInput{
+		TableName: aws.String(table),
+	})
+
+	var items []map[string]any
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan the table '%s': %w", table, err)
+		}
+
+		var page []map[string]any
+		if err := attributevalue.UnmarshalListOfMaps(output.Items, &page); err != nil {
+			return nil, fmt.Errorf("failed to decode the items of '%s': %w", table, err)
+		}
+		items = append(items, page...)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,147 @@
+package localstack_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	dynamotypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/jfelipearaujo/testcontainers/pkg/container/localstack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name string, content string) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+
+	return file
+}
+
+func TestReadTableSchema(t *testing.T) {
+	t.Run("Should read the schema and default to on-demand billing", func(t *testing.T) {
+		// Arrange
+		file := writeFile(t, "orders.table.json", `{
+			"TableName": "orders",
+			"KeySchema": [{"AttributeName": "id", "KeyType": "HASH"}],
+			"AttributeDefinitions": [{"AttributeName": "id", "AttributeType": "S"}]
+		}`)
+
+		// Act
+		input, err := localstack.ReadTableSchema(file)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "orders", aws.ToString(input.TableName))
+		assert.Equal(t, dynamotypes.KeyTypeHash, input.KeySchema[0].KeyType)
+		assert.Equal(t, dynamotypes.ScalarAttributeTypeS, input.AttributeDefinitions[0].AttributeType)
+		assert.Equal(t, dynamotypes.BillingModePayPerRequest, input.BillingMode)
+	})
+
+	t.Run("Should return an error when the table has no name", func(t *testing.T) {
+		// Arrange
+		file := writeFile(t, "orders.table.json", `{}`)
+
+		// Act
+		_, err := localstack.ReadTableSchema(file)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestReadItems(t *testing.T) {
+	t.Run("Should convert the JSON objects to items", func(t *testing.T) {
+		// Arrange
+		file := writeFile(t, "orders.json", `[{"id": "1", "total": 10, "paid": true}]`)
+
+		// Act
+		items, err := localstack.ReadItems(file)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, &dynamotypes.AttributeValueMemberS{Value: "1"}, items[0]["id"])
+		assert.Equal(t, &dynamotypes.AttributeValueMemberN{Value: "10"}, items[0]["total"])
+		assert.Equal(t, &dynamotypes.AttributeValueMemberBOOL{Value: true}, items[0]["paid"])
+	})
+}
+
+func TestLoadItems(t *testing.T) {
+	// newServer answers the BatchWriteItem calls, leaving the item unprocessed for the given number of calls
+	newServer := func(calls *int, unprocessed int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*calls++
+			w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+			if *calls <= unprocessed {
+				w.Write([]byte(`{"UnprocessedItems": {"orders": [{"PutRequest": {"Item": {"id": {"S": "1"}}}}]}}`))
+				return
+			}
+			w.Write([]byte(`{"UnprocessedItems": {}}`))
+		}))
+	}
+
+	t.Run("Should write again the unprocessed items", func(t *testing.T) {
+		// Arrange
+		defer localstack.SetUnprocessedBackoff(time.Millisecond)()
+
+		var calls int
+		server := newServer(&calls, 2)
+		defer server.Close()
+
+		file := writeFile(t, "orders.json", `[{"id": "1"}]`)
+
+		// Act
+		err := localstack.LoadItems(context.Background(), localstack.NewAWSConfig(server.URL), "orders", file)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("Should give up when the items stay unprocessed", func(t *testing.T) {
+		// Arrange
+		defer localstack.SetUnprocessedBackoff(time.Millisecond)()
+
+		var calls int
+		server := newServer(&calls, 100)
+		defer server.Close()
+
+		file := writeFile(t, "orders.json", `[{"id": "1"}]`)
+
+		// Act
+		err := localstack.LoadItems(context.Background(), localstack.NewAWSConfig(server.URL), "orders", file)
+
+		// Assert
+		assert.EqualError(t, err, "failed to write the items of 'orders': 1 still unprocessed after 6 retries")
+		assert.Equal(t, 7, calls)
+	})
+
+	t.Run("Should stop waiting when the context is done", func(t *testing.T) {
+		// Arrange
+		defer localstack.SetUnprocessedBackoff(time.Minute)()
+
+		var calls int
+		server := newServer(&calls, 100)
+		defer server.Close()
+
+		file := writeFile(t, "orders.json", `[{"id": "1"}]`)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		// Act
+		err := localstack.LoadItems(ctx, localstack.NewAWSConfig(server.URL), "orders", file)
+
+		// Assert
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 1, calls)
+	})
+}
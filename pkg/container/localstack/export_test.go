@@ -0,0 +1,13 @@
+package localstack
+
+import "time"
+
+// SetUnprocessedBackoff replaces the first wait before writing again the unprocessed items, until the returned
+// function is called
+func SetUnprocessedBackoff(backoff time.Duration) (restore func()) {
+	previous := unprocessedBackoff
+	unprocessedBackoff = backoff
+	return func() {
+		unprocessedBackoff = previous
+	}
+}
@@ -0,0 +1,319 @@
+package localstack
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	logstypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+)
+
+const (
+	LambdaRuntime lambdatypes.Runtime = lambdatypes.RuntimeProvidedal2
+	LambdaHandler string              = "bootstrap"
+	LambdaRole    string              = "arn:aws:iam::" + AccountID + ":role/lambda-role"
+	LambdaTimeout time.Duration       = 2 * time.Minute
+)
+
+// EventSource is a type that represents the trigger of a Lambda function, either an SQS queue or an SNS topic
+type EventSource struct {
+	QueueARN  string
+	TopicARN  string
+	BatchSize int32
+}
+
+// Function is a type that represents a Lambda function to be deployed
+//
+// Path is either the binary of the function, packaged as the handler, or a directory packaged as is
+//
+//	Default options:
+//		Handler: "bootstrap"
+//		Runtime: "provided.al2"
+//		Role: "arn:aws:iam::000000000000:role/lambda-role"
+type Function struct {
+	Name         string
+	Path         string
+	Handler      string
+	Runtime      lambdatypes.Runtime
+	Role         string
+	Architecture lambdatypes.Architecture
+	Environment  map[string]string
+	Timeout      int32
+	MemorySize   int32
+	EventSources []EventSource
+}
+
+// DeployedFunction is a type that represents the identifiers of a deployed Lambda function
+type DeployedFunction struct {
+	Name                string
+	ARN                 string
+	EventSourceMappings []string
+	Subscriptions       []string
+}
+
+// DeployLambda packages and creates the function, waits for it to be active, then wires its event sources
+//
+// The LocalStack container must be created with WithDockerSocket, as the functions run in their own containers.
+// The binary of a Go function must be built for Linux:
+//
+//	GOOS=linux GOARCH=amd64 go build -tags lambda.norpc -o ./bin/bootstrap ./cmd/consumer
+//
+// Example:
+//
+//	deployed, err := localstack.DeployLambda(ctx, cfg, localstack.Function{
+//		Name: "consumer",
+//		Path: "./bin/bootstrap",
+//		EventSources: []localstack.EventSource{
+//			{QueueARN: provisioned.QueueARN("orders")},
+//		},
+//	})
+func DeployLambda(ctx context.Context, cfg aws.Config, function Function) (*DeployedFunction, error) {
+	handler := valueOrDefault(function.Handler, LambdaHandler)
+
+	code, err := PackageFunction(function.Path, handler)
+	if err != nil {
+		return nil, fmt.Errorf("failed to package the function '%s': %w", function.Name, err)
+	}
+
+	input := &lambda.CreateFunctionInput{
+		FunctionName: aws.String(function.Name),
+		Handler:      aws.String(handler),
+		Runtime:      function.Runtime,
+		Role:         aws.String(valueOrDefault(function.Role, LambdaRole)),
+		Code: &lambdatypes.FunctionCode{
+			ZipFile: code,
+		},
+		Environment: &lambdatypes.Environment{
+			Variables: function.Environment,
+		},
+	}
+	if input.Runtime == "" {
+		input.Runtime = LambdaRuntime
+	}
+	if function.Architecture != "" {
+		input.Architectures = []lambdatypes.Architecture{function.Architecture}
+	}
+	if function.Timeout > 0 {
+		input.Timeout = aws.Int32(function.Timeout)
+	}
+	if function.MemorySize > 0 {
+		input.MemorySize = aws.Int32(function.MemorySize)
+	}
+
+	client := lambda.NewFromConfig(cfg)
+
+	output, err := client.CreateFunction(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the function '%s': %w", function.Name, err)
+	}
+
+	deployed := &DeployedFunction{
+		Name: function.Name,
+		ARN:  aws.ToString(output.FunctionArn),
+	}
+
+	err = lambda.NewFunctionActiveWaiter(client).Wait(ctx, &lambda.GetFunctionConfigurationInput{
+		FunctionName: aws.String(function.Name),
+	}, LambdaTimeout)
+	if err != nil {
+		return deployed, fmt.Errorf("failed to wait for the function '%s' to be active: %w", function.Name, err)
+	}
+
+	for _, source := range function.EventSources {
+		switch {
+		case source.QueueARN != "":
+			uuid, err := createEventSourceMapping(ctx, client, function.Name, source)
+			if err != nil {
+				return deployed, err
+			}
+			deployed.EventSourceMappings = append(deployed.EventSourceMappings, uuid)
+		case source.TopicARN != "":
+			arn, err := subscribeFunction(ctx, cfg, client, deployed, source)
+			if err != nil {
+				return deployed, err
+			}
+			deployed.Subscriptions = append(deployed.Subscriptions, arn)
+		default:
+			return deployed, fmt.Errorf("the event source of the function '%s' has no queue nor topic", function.Name)
+		}
+	}
+
+	return deployed, nil
+}
+
+// FunctionLogs returns the log messages of the function, to be reported when a scenario fails
+func FunctionLogs(ctx context.Context, cfg aws.Config, name string) (string, error) {
+	client := cloudwatchlogs.NewFromConfig(cfg)
+	paginator := cloudwatchlogs.NewFilterLogEventsPaginator(client, &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName: aws.String("/aws/lambda/" + name),
+	})
+
+	var sb strings.Builder
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			var notFound *logstypes.ResourceNotFoundException
+			if errors.As(err, &notFound) {
+				return sb.String(), nil
+			}
+			return sb.String(), fmt.Errorf("failed to read the logs of the function '%s': %w", name, err)
+		}
+
+		for _, event := range output.Events {
+			sb.WriteString(aws.ToString(event.Message))
+			if !strings.HasSuffix(aws.ToString(event.Message), "\n") {
+				sb.WriteString("\n")
+			}
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// PackageFunction returns the zip archive of the given binary, named as the handler, or of the given directory
+func PackageFunction(path string, handler string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	if info.IsDir() {
+		err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+
+			name, err := filepath.Rel(path, file)
+			if err != nil {
+				return err
+			}
+
+			return addZipFile(archive, file, filepath.ToSlash(name))
+		})
+	} else {
+		err = addZipFile(archive, path, handler)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func addZipFile(archive *zip.Writer, file string, name string) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	header.Method = zip.Deflate
+	// the bootstrap of the provided runtimes must be executable
+	header.SetMode(info.Mode() | 0555)
+
+	writer, err := archive.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(writer, f)
+	return err
+}
+
+func createEventSourceMapping(ctx context.Context, client *lambda.Client, name string, source EventSource) (string, error) {
+	input := &lambda.CreateEventSourceMappingInput{
+		FunctionName:   aws.String(name),
+		EventSourceArn: aws.String(source.QueueARN),
+	}
+	if source.BatchSize > 0 {
+		input.BatchSize = aws.Int32(source.BatchSize)
+	}
+
+	output, err := client.CreateEventSourceMapping(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("failed to map the queue '%s' to the function '%s': %w", source.QueueARN, name, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, LambdaTimeout)
+	defer cancel()
+
+	for {
+		mapping, err := client.GetEventSourceMapping(ctx, &lambda.GetEventSourceMappingInput{
+			UUID: output.UUID,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to get the event source mapping of the function '%s': %w", name, err)
+		}
+		if aws.ToString(mapping.State) == "Enabled" {
+			return aws.ToString(output.UUID), nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("the event source mapping of the function '%s' is not enabled: %s", name, aws.ToString(mapping.State))
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+func subscribeFunction(ctx context.Context, cfg aws.Config, client *lambda.Client, deployed *DeployedFunction, source EventSource) (string, error) {
+	_, err := client.AddPermission(ctx, &lambda.AddPermissionInput{
+		FunctionName: aws.String(deployed.Name),
+		StatementId:  aws.String(fmt.Sprintf("sns-%d", len(deployed.Subscriptions))),
+		Action:       aws.String("lambda:InvokeFunction"),
+		Principal:    aws.String("sns.amazonaws.com"),
+		SourceArn:    aws.String(source.TopicARN),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to allow the topic '%s' to invoke the function '%s': %w", source.TopicARN, deployed.Name, err)
+	}
+
+	output, err := sns.NewFromConfig(cfg).Subscribe(ctx, &sns.SubscribeInput{
+		TopicArn:              aws.String(source.TopicARN),
+		Protocol:              aws.String("lambda"),
+		Endpoint:              aws.String(deployed.ARN),
+		ReturnSubscriptionArn: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to subscribe the function '%s' to the topic '%s': %w", deployed.Name, source.TopicARN, err)
+	}
+
+	return aws.ToString(output.SubscriptionArn), nil
+}
+
+func valueOrDefault(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,55 @@
+package localstack_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container/localstack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageFunction(t *testing.T) {
+	t.Run("Should package a binary as an executable handler", func(t *testing.T) {
+		// Arrange
+		file := writeFile(t, "consumer", "binary")
+
+		// Act
+		code, err := localstack.PackageFunction(file, localstack.LambdaHandler)
+
+		// Assert
+		require.NoError(t, err)
+
+		archive, err := zip.NewReader(bytes.NewReader(code), int64(len(code)))
+		require.NoError(t, err)
+		require.Len(t, archive.File, 1)
+		assert.Equal(t, "bootstrap", archive.File[0].Name)
+		assert.NotZero(t, archive.File[0].Mode()&0100)
+	})
+
+	t.Run("Should package the files of a directory", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "bootstrap"), []byte("binary"), 0755))
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "config"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "config", "app.json"), []byte("{}"), 0644))
+
+		// Act
+		code, err := localstack.PackageFunction(dir, localstack.LambdaHandler)
+
+		// Assert
+		require.NoError(t, err)
+
+		archive, err := zip.NewReader(bytes.NewReader(code), int64(len(code)))
+		require.NoError(t, err)
+
+		var names []string
+		for _, file := range archive.File {
+			names = append(names, file.Name)
+		}
+		assert.Equal(t, []string{"bootstrap", "config/app.json"}, names)
+	})
+}
@@ -3,34 +3,88 @@ package localstack
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	dockercontainer "github.com/docker/docker/api/types/container"
 	"github.com/docker/go-connections/nat"
 	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/jfelipearaujo/testcontainers/pkg/network"
 	"github.com/testcontainers/testcontainers-go"
-	"github.com/testcontainers/testcontainers-go/wait"
 )
 
 const (
 	BasePath      string = "/etc/localstack/init/ready.d"
+	Image         string = "localstack/localstack"
+	ImageTag      string = "3.4"
 	ExposedPort   string = "4566"
 	Debug         string = "false"
 	DockerHost    string = "unix:///var/run/docker.sock"
 	DefaultRegion string = "us-east-1"
+	DockerSocket  string = "/var/run/docker.sock"
+)
+
+// SQS endpoint strategies, see https://docs.localstack.cloud/user-guide/aws/sqs/#queue-urls
+const (
+	SQSEndpointStandard string = "standard"
+	SQSEndpointDomain   string = "domain"
+	SQSEndpointPath     string = "path"
+	SQSEndpointOff      string = "off"
+)
+
+// Service is a type that represents an AWS service emulated by LocalStack
+type Service string
+
+const (
+	APIGateway     Service = "apigateway"
+	CloudFormation Service = "cloudformation"
+	CloudWatch     Service = "cloudwatch"
+	CloudWatchLogs Service = "logs"
+	DynamoDB       Service = "dynamodb"
+	EventBridge    Service = "events"
+	IAM            Service = "iam"
+	Kinesis        Service = "kinesis"
+	KMS            Service = "kms"
+	Lambda         Service = "lambda"
+	S3             Service = "s3"
+	SecretsManager Service = "secretsmanager"
+	SNS            Service = "sns"
+	SQS            Service = "sqs"
+	SSM            Service = "ssm"
+	StepFunctions  Service = "stepfunctions"
+	STS            Service = "sts"
 )
 
 // Options is a type that represents the options for a LocalStack container
 //
 //	Default options:
+//		ImageTag: "3.4"
 //		ExposedPort: "4566"
 //		Debug: false
 //		DockerHost: "unix:///var/run/docker.sock"
 //		DefaultRegion: "us-east-1"
+//		Services: nil (all the services)
+//		Persistence: false
+//		MountDockerSocket: false
+//		Resources: no resources
+//		SQSEndpointStrategy: "" ("off" when the container is in a network)
+//		Seed: no secrets nor parameters
+//		SeedFiles: nil
 type Options struct {
-	ExposedPort   string
-	Debug         string
-	DockerHost    string
-	DefaultRegion string
+	ImageTag          string
+	ExposedPort       string
+	Debug             string
+	DockerHost        string
+	DefaultRegion     string
+	Services          []Service
+	Persistence       bool
+	MountDockerSocket bool
+	Resources         Resources
+	Seed              Seed
+	SeedFiles         []string
+
+	SQSEndpointStrategy string
+	NetworkAlias        *string
 }
 
 // LocalStackOption is a type that represents a LocalStack option
@@ -72,20 +126,84 @@ func WithDefaultRegion(defaultRegion string) LocalStackOption {
 	}
 }
 
-// BuildEndpoint returns the endpoint of the LocalStack container
+// WithImageTag is a LocalStackOption that sets the tag of the LocalStack image
 //
-//	Example: "http://localhost:4566"
-func BuildEndpoint(ctx context.Context, container testcontainers.Container, opts ...LocalStackOption) (string, error) {
-	options := &Options{
-		ExposedPort:   ExposedPort,
-		Debug:         Debug,
-		DockerHost:    DockerHost,
-		DefaultRegion: DefaultRegion,
+//	Default: "3.4"
+func WithImageTag(tag string) LocalStackOption {
+	return func(options *Options) {
+		options.ImageTag = tag
 	}
+}
 
-	for _, o := range opts {
-		o(options)
+// WithServices is a LocalStackOption that sets the services started by the LocalStack container
+//
+//	Default: nil (all the services)
+//
+// Example:
+//
+//	localstack.WithServices(localstack.SQS, localstack.SNS)
+func WithServices(services ...Service) LocalStackOption {
+	return func(options *Options) {
+		options.Services = append(options.Services, services...)
 	}
+}
+
+// WithPersistence is a LocalStackOption that enables the persistence of the LocalStack state
+//
+//	Default: false
+func WithPersistence(persistence bool) LocalStackOption {
+	return func(options *Options) {
+		options.Persistence = persistence
+	}
+}
+
+// WithDockerSocket is a LocalStackOption that mounts the Docker socket of the host into the LocalStack container,
+// which is needed to run Lambda functions
+//
+//	Default: false
+func WithDockerSocket() LocalStackOption {
+	return func(options *Options) {
+		options.MountDockerSocket = true
+	}
+}
+
+// WithSQSEndpointStrategy is a LocalStackOption that sets the strategy used to build the SQS queue URLs
+//
+//	Default: "" ("off" when the container is in a network, so the queue URLs use the network alias)
+func WithSQSEndpointStrategy(strategy string) LocalStackOption {
+	return func(options *Options) {
+		options.SQSEndpointStrategy = strategy
+	}
+}
+
+// WithNetwork is a LocalStackOption that sets the network alias of the LocalStack container, which is also
+// used as LOCALSTACK_HOST so the generated queue URLs resolve from the other containers of the network
+//
+//	Default: nil
+func WithNetwork(network *network.Network) LocalStackOption {
+	return func(options *Options) {
+		options.NetworkAlias = &network.Alias
+	}
+}
+
+// BuildInternalEndpoint returns the endpoint of the LocalStack container when the container is in a network
+//
+//	Example: "http://network_alias:4566"
+func BuildInternalEndpoint(ctx context.Context, container testcontainers.Container, opts ...LocalStackOption) (string, error) {
+	options := buildOptions(opts...)
+
+	if options.NetworkAlias == nil {
+		return "", fmt.Errorf("the container is not in a network")
+	}
+
+	return fmt.Sprintf("http://%s:%s", *options.NetworkAlias, nat.Port(options.ExposedPort).Port()), nil
+}
+
+// BuildEndpoint returns the endpoint of the LocalStack container
+//
+//	Example: "http://localhost:4566"
+func BuildEndpoint(ctx context.Context, container testcontainers.Container, opts ...LocalStackOption) (string, error) {
+	options := buildOptions(opts...)
 
 	host, err := container.Host(ctx)
 	if err != nil {
@@ -100,6 +218,22 @@ func BuildEndpoint(ctx context.Context, container testcontainers.Container, opts
 	return fmt.Sprintf("http://%s:%s", host, port.Port()), nil
 }
 
+func buildOptions(opts ...LocalStackOption) *Options {
+	options := &Options{
+		ImageTag:      ImageTag,
+		ExposedPort:   ExposedPort,
+		Debug:         Debug,
+		DockerHost:    DockerHost,
+		DefaultRegion: DefaultRegion,
+	}
+
+	for _, o := range opts {
+		o(options)
+	}
+
+	return options
+}
+
 // Return a new container definition for a LocalStack container with default options:
 //
 //	DockerImage: "localstack/localstack:3.4"
@@ -109,23 +243,88 @@ func BuildEndpoint(ctx context.Context, container testcontainers.Container, opts
 //		DEBUG: false
 //		DOCKER_HOST: "unix:///var/run/docker.sock"
 //		DEFAULT_REGION: "us-east-1"
+//		AWS_DEFAULT_REGION: "us-east-1"
+//		SERVICES: set by WithServices
+//		PERSISTENCE: set by WithPersistence
+//		LOCALSTACK_HOST: "network_alias:4566" when set by WithNetwork
+//		SQS_ENDPOINT_STRATEGY: set by WithSQSEndpointStrategy
 //
 //	BasePath: "/etc/localstack/init/ready.d"
-//	WaitingForLog: "Initialization complete!"
-//	StartupTimeout: "30 seconds"
-func WithLocalStackContainer() container.ContainerOption {
+//	WaitingForHealth: requested services available and init scripts completed
+//	StartupTimeout: "60 seconds"
+//
+// The secrets and parameters set by WithSecrets, WithParameters and WithSeedFiles are seeded, then the resources
+// set by WithResources are provisioned once the container is ready.
+//
+// The same LocalStackOptions must be given to BuildEndpoint and BuildInternalEndpoint, so the endpoints match the container.
+//
+// Example:
+//
+//	definition := container.NewContainerDefinition(
+//		localstack.WithLocalStackContainer(
+//			localstack.WithDefaultRegion("eu-west-1"),
+//			localstack.WithServices(localstack.SQS, localstack.SNS),
+//		),
+//	)
+func WithLocalStackContainer(opts ...LocalStackOption) container.ContainerOption {
+	options := buildOptions(opts...)
+
+	env := map[string]string{
+		"DEBUG":              options.Debug,
+		"DOCKER_HOST":        options.DockerHost,
+		"DEFAULT_REGION":     options.DefaultRegion,
+		"AWS_DEFAULT_REGION": options.DefaultRegion,
+	}
+
+	if len(options.Services) > 0 {
+		services := make([]string, len(options.Services))
+		for i, service := range options.Services {
+			services[i] = string(service)
+		}
+		env["SERVICES"] = strings.Join(services, ",")
+	}
+
+	if options.Persistence {
+		env["PERSISTENCE"] = "1"
+	}
+
+	sqsEndpointStrategy := options.SQSEndpointStrategy
+	if options.NetworkAlias != nil {
+		env["LOCALSTACK_HOST"] = fmt.Sprintf("%s:%s", *options.NetworkAlias, nat.Port(options.ExposedPort).Port())
+		if sqsEndpointStrategy == "" {
+			sqsEndpointStrategy = SQSEndpointOff
+		}
+	}
+
+	if sqsEndpointStrategy != "" {
+		env["SQS_ENDPOINT_STRATEGY"] = sqsEndpointStrategy
+	}
+
 	return func(container *container.Container) {
-		container.ContainerRequest.Image = "localstack/localstack:3.4"
+		container.ContainerRequest.Image = fmt.Sprintf("%s:%s", Image, options.ImageTag)
 		container.ContainerRequest.ExposedPorts = []string{
-			ExposedPort,
+			options.ExposedPort,
 		}
-		container.ContainerRequest.Env = map[string]string{
-			"DEBUG":          Debug,
-			"DOCKER_HOST":    DockerHost,
-			"DEFAULT_REGION": DefaultRegion,
+		container.ContainerRequest.Env = env
+		container.ContainerRequest.WaitingFor = ForHealth(opts...).
+			WithStartupTimeout(60 * time.Second)
+
+		if options.MountDockerSocket {
+			modifier := container.ContainerRequest.HostConfigModifier
+			container.ContainerRequest.HostConfigModifier = func(hostConfig *dockercontainer.HostConfig) {
+				if modifier != nil {
+					modifier(hostConfig)
+				}
+				hostConfig.Binds = append(hostConfig.Binds, DockerSocket+":"+DockerSocket)
+			}
+		}
+
+		if !options.Seed.IsEmpty() || len(options.SeedFiles) > 0 {
+			withSeeding(container, opts...)
+		}
+
+		if !options.Resources.IsEmpty() {
+			withProvisioning(container, opts...)
 		}
-		container.ContainerRequest.WaitingFor = wait.
-			ForLog("Initialization complete!").
-			WithStartupTimeout(30 * time.Second)
 	}
 }
@@ -0,0 +1,89 @@
+package localstack_test
+
+import (
+	"context"
+	"testing"
+
+	dockercontainer "github.com/docker/docker/api/types/container"
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/jfelipearaujo/testcontainers/pkg/container/localstack"
+	"github.com/jfelipearaujo/testcontainers/pkg/network"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLocalStackContainer(t *testing.T) {
+	t.Run("Should use the default options", func(t *testing.T) {
+		// Act
+		definition := container.NewContainerDefinition(
+			localstack.WithLocalStackContainer(),
+		)
+
+		// Assert
+		assert.Equal(t, "localstack/localstack:3.4", definition.ContainerRequest.Image)
+		assert.Equal(t, map[string]string{
+			"DEBUG":              localstack.Debug,
+			"DOCKER_HOST":        localstack.DockerHost,
+			"DEFAULT_REGION":     localstack.DefaultRegion,
+			"AWS_DEFAULT_REGION": localstack.DefaultRegion,
+		}, definition.ContainerRequest.Env)
+		assert.Nil(t, definition.ContainerRequest.HostConfigModifier)
+	})
+
+	t.Run("Should honor the options", func(t *testing.T) {
+		// Act
+		definition := container.NewContainerDefinition(
+			localstack.WithLocalStackContainer(
+				localstack.WithImageTag("3.5"),
+				localstack.WithDefaultRegion("eu-west-1"),
+				localstack.WithServices(localstack.SQS, localstack.SNS),
+				localstack.WithPersistence(true),
+				localstack.WithDockerSocket(),
+			),
+		)
+
+		// Assert
+		assert.Equal(t, "localstack/localstack:3.5", definition.ContainerRequest.Image)
+		assert.Equal(t, "eu-west-1", definition.ContainerRequest.Env["DEFAULT_REGION"])
+		assert.Equal(t, "eu-west-1", definition.ContainerRequest.Env["AWS_DEFAULT_REGION"])
+		assert.Equal(t, "sqs,sns", definition.ContainerRequest.Env["SERVICES"])
+		assert.Equal(t, "1", definition.ContainerRequest.Env["PERSISTENCE"])
+
+		hostConfig := &dockercontainer.HostConfig{}
+		definition.ContainerRequest.HostConfigModifier(hostConfig)
+		assert.Equal(t, []string{"/var/run/docker.sock:/var/run/docker.sock"}, hostConfig.Binds)
+	})
+
+	t.Run("Should use the network alias as LocalStack host", func(t *testing.T) {
+		// Act
+		definition := container.NewContainerDefinition(
+			localstack.WithLocalStackContainer(
+				localstack.WithNetwork(network.NewNetwork(network.WithAlias("aws"))),
+			),
+		)
+
+		// Assert
+		assert.Equal(t, "aws:4566", definition.ContainerRequest.Env["LOCALSTACK_HOST"])
+		assert.Equal(t, localstack.SQSEndpointOff, definition.ContainerRequest.Env["SQS_ENDPOINT_STRATEGY"])
+	})
+}
+
+func TestBuildInternalEndpoint(t *testing.T) {
+	t.Run("Should use the network alias", func(t *testing.T) {
+		// Act
+		endpoint, err := localstack.BuildInternalEndpoint(context.Background(), nil,
+			localstack.WithNetwork(network.NewNetwork(network.WithAlias("aws"))),
+		)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "http://aws:4566", endpoint)
+	})
+
+	t.Run("Should return an error when the container is not in a network", func(t *testing.T) {
+		// Act
+		_, err := localstack.BuildInternalEndpoint(context.Background(), nil)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,79 @@
+package localstack
+
+import (
+	"context"
+	"time"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+var _ container.Module = (*Module)(nil)
+
+// Module is a type that represents the LocalStack module
+type Module struct {
+	opts []LocalStackOption
+}
+
+// NewModule returns the LocalStack module with the given options
+//
+// Example:
+//
+//	module := localstack.NewModule(
+//		localstack.WithServices(localstack.SQS),
+//		localstack.WithResources(resources),
+//		localstack.WithNetwork(ntwrkDefinition),
+//	)
+func NewModule(opts ...LocalStackOption) *Module {
+	return &Module{
+		opts: opts,
+	}
+}
+
+// Definition returns the options of the LocalStack container definition
+func (m *Module) Definition() []container.ContainerOption {
+	return []container.ContainerOption{
+		WithLocalStackContainer(m.opts...),
+	}
+}
+
+// Readiness returns the strategy used to wait for the services and the init scripts of the LocalStack container
+func (m *Module) Readiness() wait.Strategy {
+	return ForHealth(m.opts...).
+		WithStartupTimeout(60 * time.Second)
+}
+
+// InternalEndpoint returns the endpoint of the container for the containers of the same network
+func (m *Module) InternalEndpoint(ctx context.Context, container testcontainers.Container) (string, error) {
+	return BuildInternalEndpoint(ctx, container, m.opts...)
+}
+
+// ExternalEndpoint returns the endpoint of the container for the host
+func (m *Module) ExternalEndpoint(ctx context.Context, container testcontainers.Container) (string, error) {
+	return BuildEndpoint(ctx, container, m.opts...)
+}
+
+// Reset resets the state of the services, then seeds and provisions again, see ResetAndProvision
+func (m *Module) Reset(ctx context.Context, container testcontainers.Container) error {
+	_, err := ResetAndProvision(ctx, container, m.opts...)
+	return err
+}
+
+// EnvForDependents returns the endpoint, the region and the static credentials used by the AWS SDKs
+func (m *Module) EnvForDependents(ctx context.Context, container testcontainers.Container) (map[string]string, error) {
+	endpoint, err := BuildInternalEndpoint(ctx, container, m.opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	options := buildOptions(m.opts...)
+
+	return map[string]string{
+		"AWS_ENDPOINT_URL":      endpoint,
+		"AWS_REGION":            options.DefaultRegion,
+		"AWS_DEFAULT_REGION":    options.DefaultRegion,
+		"AWS_ACCESS_KEY_ID":     AccessKeyID,
+		"AWS_SECRET_ACCESS_KEY": SecretAccessKey,
+	}, nil
+}
@@ -0,0 +1,34 @@
+package localstack_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container/localstack"
+	"github.com/jfelipearaujo/testcontainers/pkg/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModule(t *testing.T) {
+	t.Run("Should return the environment of the dependents", func(t *testing.T) {
+		// Arrange
+		module := localstack.NewModule(
+			localstack.WithDefaultRegion("eu-west-1"),
+			localstack.WithNetwork(network.NewNetwork(network.WithAlias("aws"))),
+		)
+
+		// Act
+		env, err := module.EnvForDependents(context.Background(), nil)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"AWS_ENDPOINT_URL":      "http://aws:4566",
+			"AWS_REGION":            "eu-west-1",
+			"AWS_DEFAULT_REGION":    "eu-west-1",
+			"AWS_ACCESS_KEY_ID":     "test",
+			"AWS_SECRET_ACCESS_KEY": "test",
+		}, env)
+	})
+}
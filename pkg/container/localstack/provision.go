@@ -0,0 +1,543 @@
+package localstack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamotypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	fifoSuffix      string = ".fifo"
+	MaxReceiveCount int    = 3
+)
+
+// Queue is a type that represents an SQS queue to be provisioned
+//
+// The ".fifo" suffix is appended to the name of FIFO queues when missing, DeadLetterQueue is the name of
+// another queue of the same Resources
+type Queue struct {
+	Name                      string
+	FIFO                      bool
+	ContentBasedDeduplication bool
+	DeadLetterQueue           string
+	MaxReceiveCount           int
+	Attributes                map[string]string
+}
+
+// Topic is a type that represents an SNS topic to be provisioned
+type Topic struct {
+	Name       string
+	FIFO       bool
+	Attributes map[string]string
+}
+
+// Subscription is a type that represents the subscription of an SQS queue to an SNS topic, both declared
+// in the same Resources
+//
+// FilterPolicy is the JSON filter policy, applied to the message attributes unless FilterPolicyScope is "MessageBody"
+type Subscription struct {
+	Topic              string
+	Queue              string
+	FilterPolicy       string
+	FilterPolicyScope  string
+	RawMessageDelivery bool
+}
+
+// Bucket is a type that represents an S3 bucket to be provisioned
+type Bucket struct {
+	Name string
+}
+
+// Key is a type that represents a key attribute of a DynamoDB table
+type Key struct {
+	Name string
+	Type dynamotypes.ScalarAttributeType
+}
+
+// TableIndex is a type that represents a global secondary index of a DynamoDB table
+type TableIndex struct {
+	Name         string
+	PartitionKey Key
+	SortKey      *Key
+}
+
+// Table is a type that represents an on-demand DynamoDB table to be provisioned
+type Table struct {
+	Name         string
+	PartitionKey Key
+	SortKey      *Key
+	Indexes      []TableIndex
+}
+
+// Resources is a type that represents the AWS resources to be provisioned
+//
+// Example:
+//
+//	localstack.Resources{
+//		Queues: []localstack.Queue{
+//			{Name: "orders-dlq"},
+//			{Name: "orders", DeadLetterQueue: "orders-dlq"},
+//		},
+//		Topics: []localstack.Topic{
+//			{Name: "events"},
+//		},
+//		Subscriptions: []localstack.Subscription{
+//			{Topic: "events", Queue: "orders", FilterPolicy: `{"type": ["order_created"]}`},
+//		},
+//	}
+type Resources struct {
+	Queues        []Queue
+	Topics        []Topic
+	Subscriptions []Subscription
+	Buckets       []Bucket
+	Tables        []Table
+}
+
+// IsEmpty returns true when no resource is declared
+func (r Resources) IsEmpty() bool {
+	return len(r.Queues) == 0 &&
+		len(r.Topics) == 0 &&
+		len(r.Subscriptions) == 0 &&
+		len(r.Buckets) == 0 &&
+		len(r.Tables) == 0
+}
+
+// Validate returns an error when a resource has no name or references a resource that is not declared
+func (r Resources) Validate() error {
+	var errs []error
+
+	queues := make(map[string]bool, len(r.Queues))
+	for _, queue := range r.Queues {
+		if queue.Name == "" {
+			errs = append(errs, fmt.Errorf("queue without name"))
+		}
+		queues[queue.Name] = true
+	}
+	for _, queue := range r.Queues {
+		if queue.DeadLetterQueue != "" && !queues[queue.DeadLetterQueue] {
+			errs = append(errs, fmt.Errorf("queue '%s': dead-letter queue '%s' is not declared", queue.Name, queue.DeadLetterQueue))
+		}
+	}
+
+	topics := make(map[string]bool, len(r.Topics))
+	for _, topic := range r.Topics {
+		if topic.Name == "" {
+			errs = append(errs, fmt.Errorf("topic without name"))
+		}
+		topics[topic.Name] = true
+	}
+
+	for _, subscription := range r.Subscriptions {
+		if !topics[subscription.Topic] {
+			errs = append(errs, fmt.Errorf("subscription: topic '%s' is not declared", subscription.Topic))
+		}
+		if !queues[subscription.Queue] {
+			errs = append(errs, fmt.Errorf("subscription: queue '%s' is not declared", subscription.Queue))
+		}
+	}
+
+	for _, bucket := range r.Buckets {
+		if bucket.Name == "" {
+			errs = append(errs, fmt.Errorf("bucket without name"))
+		}
+	}
+
+	for _, table := range r.Tables {
+		if table.Name == "" || table.PartitionKey.Name == "" {
+			errs = append(errs, fmt.Errorf("table '%s': the name and the partition key are required", table.Name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r *Resources) merge(other Resources) {
+	r.Queues = append(r.Queues, other.Queues...)
+	r.Topics = append(r.Topics, other.Topics...)
+	r.Subscriptions = append(r.Subscriptions, other.Subscriptions...)
+	r.Buckets = append(r.Buckets, other.Buckets...)
+	r.Tables = append(r.Tables, other.Tables...)
+}
+
+// ProvisionedQueue is a type that represents the identifiers of a provisioned SQS queue
+type ProvisionedQueue struct {
+	URL string
+	ARN string
+}
+
+// Provisioned is a type that represents the identifiers of the provisioned resources, indexed by the declared names
+type Provisioned struct {
+	Queues        map[string]ProvisionedQueue
+	Topics        map[string]string
+	Subscriptions []string
+	Buckets       map[string]string
+	Tables        map[string]string
+}
+
+// QueueURL returns the URL of the given queue, or an empty string when it was not provisioned
+func (p *Provisioned) QueueURL(name string) string {
+	return p.Queues[name].URL
+}
+
+// QueueARN returns the ARN of the given queue, or an empty string when it was not provisioned
+func (p *Provisioned) QueueARN(name string) string {
+	return p.Queues[name].ARN
+}
+
+// TopicARN returns the ARN of the given topic, or an empty string when it was not provisioned
+func (p *Provisioned) TopicARN(name string) string {
+	return p.Topics[name]
+}
+
+// WithResources is a LocalStackOption that provisions the given resources once the LocalStack container is ready,
+// it can be given more than once
+//
+//	Default: no resources
+//
+// As Provision is idempotent, the identifiers of the resources can be retrieved by calling it with the same Resources.
+//
+// Example:
+//
+//	localstack.WithResources(localstack.Resources{
+//		Queues: []localstack.Queue{{Name: "orders"}},
+//	})
+func WithResources(resources Resources) LocalStackOption {
+	return func(options *Options) {
+		options.Resources.merge(resources)
+	}
+}
+
+// Provision creates the given resources, queues first, then topics, subscriptions, buckets and tables
+//
+// It is idempotent: the resources that already exist are kept and their identifiers are returned.
+//
+// Example:
+//
+//	provisioned, err := localstack.Provision(ctx, cfg, resources)
+//	if err != nil {
+//		return ctx, err
+//	}
+//
+//	queueURL := provisioned.QueueURL("orders")
+func Provision(ctx context.Context, cfg aws.Config, resources Resources) (*Provisioned, error) {
+	if err := resources.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid resources: %w", err)
+	}
+
+	provisioned := &Provisioned{
+		Queues:  make(map[string]ProvisionedQueue),
+		Topics:  make(map[string]string),
+		Buckets: make(map[string]string),
+		Tables:  make(map[string]string),
+	}
+
+	if err := provisionQueues(ctx, sqs.NewFromConfig(cfg), resources.Queues, provisioned); err != nil {
+		return nil, err
+	}
+
+	snsClient := sns.NewFromConfig(cfg)
+
+	if err := provisionTopics(ctx, snsClient, resources.Topics, provisioned); err != nil {
+		return nil, err
+	}
+
+	if err := provisionSubscriptions(ctx, snsClient, resources.Subscriptions, provisioned); err != nil {
+		return nil, err
+	}
+
+	if err := provisionBuckets(ctx, NewS3Client(cfg), cfg.Region, resources.Buckets, provisioned); err != nil {
+		return nil, err
+	}
+
+	if err := provisionTables(ctx, dynamodb.NewFromConfig(cfg), resources.Tables, provisioned); err != nil {
+		return nil, err
+	}
+
+	return provisioned, nil
+}
+
+func withProvisioning(c *container.Container, opts ...LocalStackOption) {
+	options := buildOptions(opts...)
+
+	c.ContainerRequest.LifecycleHooks = append(c.ContainerRequest.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+		PostReadies: []testcontainers.ContainerHook{
+			func(ctx context.Context, target testcontainers.Container) error {
+				cfg, err := AWSConfig(ctx, target, opts...)
+				if err != nil {
+					return err
+				}
+
+				if _, err := Provision(ctx, cfg, options.Resources); err != nil {
+					return fmt.Errorf("failed to provision the resources: %w", err)
+				}
+
+				return nil
+			},
+		},
+	})
+}
+
+func provisionQueues(ctx context.Context, client *sqs.Client, queues []Queue, provisioned *Provisioned) error {
+	for _, queue := range queues {
+		name := queue.Name
+		attributes := make(map[string]string, len(queue.Attributes)+2)
+		for key, value := range queue.Attributes {
+			attributes[key] = value
+		}
+
+		if queue.FIFO {
+			if !strings.HasSuffix(name, fifoSuffix) {
+				name += fifoSuffix
+			}
+			attributes[string(sqstypes.QueueAttributeNameFifoQueue)] = "true"
+			if queue.ContentBasedDeduplication {
+				attributes[string(sqstypes.QueueAttributeNameContentBasedDeduplication)] = "true"
+			}
+		}
+
+		output, err := client.CreateQueue(ctx, &sqs.CreateQueueInput{
+			QueueName:  aws.String(name),
+			Attributes: attributes,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create the queue '%s': %w", name, err)
+		}
+
+		queueAttributes, err := client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+			QueueUrl:       output.QueueUrl,
+			AttributeNames: []sqstypes.QueueAttributeName{sqstypes.QueueAttributeNameQueueArn},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get the ARN of the queue '%s': %w", name, err)
+		}
+
+		provisioned.Queues[queue.Name] = ProvisionedQueue{
+			URL: *output.QueueUrl,
+			ARN: queueAttributes.Attributes[string(sqstypes.QueueAttributeNameQueueArn)],
+		}
+	}
+
+	// the redrive policies are set once all the queues exist, so a queue can be declared before its dead-letter queue
+	for _, queue := range queues {
+		if queue.DeadLetterQueue == "" {
+			continue
+		}
+
+		maxReceiveCount := queue.MaxReceiveCount
+		if maxReceiveCount == 0 {
+			maxReceiveCount = MaxReceiveCount
+		}
+
+		policy := fmt.Sprintf(`{"deadLetterTargetArn":%q,"maxReceiveCount":"%d"}`,
+			provisioned.QueueARN(queue.DeadLetterQueue),
+			maxReceiveCount,
+		)
+
+		_, err := client.SetQueueAttributes(ctx, &sqs.SetQueueAttributesInput{
+			QueueUrl: aws.String(provisioned.QueueURL(queue.Name)),
+			Attributes: map[string]string{
+				string(sqstypes.QueueAttributeNameRedrivePolicy): policy,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set the dead-letter queue of '%s': %w", queue.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func provisionTopics(ctx context.Context, client *sns.Client, topics []Topic, provisioned *Provisioned) error {
+	for _, topic := range topics {
+		name := topic.Name
+		attributes := make(map[string]string, len(topic.Attributes)+1)
+		for key, value := range topic.Attributes {
+			attributes[key] = value
+		}
+
+		if topic.FIFO {
+			if !strings.HasSuffix(name, fifoSuffix) {
+				name += fifoSuffix
+			}
+			attributes["FifoTopic"] = "true"
+		}
+
+		output, err := client.CreateTopic(ctx, &sns.CreateTopicInput{
+			Name:       aws.String(name),
+			Attributes: attributes,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create the topic '%s': %w", name, err)
+		}
+
+		provisioned.Topics[topic.Name] = *output.TopicArn
+	}
+
+	return nil
+}
+
+func provisionSubscriptions(ctx context.Context, client *sns.Client, subscriptions []Subscription, provisioned *Provisioned) error {
+	for _, subscription := range subscriptions {
+		attributes := map[string]string{}
+		if subscription.FilterPolicy != "" {
+			attributes["FilterPolicy"] = subscription.FilterPolicy
+		}
+		if subscription.FilterPolicyScope != "" {
+			attributes["FilterPolicyScope"] = subscription.FilterPolicyScope
+		}
+		if subscription.RawMessageDelivery {
+			attributes["RawMessageDelivery"] = "true"
+		}
+
+		output, err := client.Subscribe(ctx, &sns.SubscribeInput{
+			TopicArn:              aws.String(provisioned.TopicARN(subscription.Topic)),
+			Protocol:              aws.String("sqs"),
+			Endpoint:              aws.String(provisioned.QueueARN(subscription.Queue)),
+			Attributes:            attributes,
+			ReturnSubscriptionArn: true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to subscribe the queue '%s' to the topic '%s': %w", subscription.Queue, subscription.Topic, err)
+		}
+
+		provisioned.Subscriptions = append(provisioned.Subscriptions, *output.SubscriptionArn)
+	}
+
+	return nil
+}
+
+func provisionBuckets(ctx context.Context, client *s3.Client, region string, buckets []Bucket, provisioned *Provisioned) error {
+	for _, bucket := range buckets {
+		input := &s3.CreateBucketInput{
+			Bucket: aws.String(bucket.Name),
+		}
+		if region != "" && region != DefaultRegion {
+			input.CreateBucketConfiguration = &s3types.CreateBucketConfiguration{
+				LocationConstraint: s3types.BucketLocationConstraint(region),
+			}
+		}
+
+		if _, err := client.CreateBucket(ctx, input); err != nil {
+			var alreadyOwned *s3types.BucketAlreadyOwnedByYou
+			if !errors.As(err, &alreadyOwned) {
+				return fmt.Errorf("failed to create the bucket '%s': %w", bucket.Name, err)
+			}
+		}
+
+		provisioned.Buckets[bucket.Name] = "arn:aws:s3:::" + bucket.Name
+	}
+
+	return nil
+}
+
+func provisionTables(ctx context.Context, client *dynamodb.Client, tables []Table, provisioned *Provisioned) error {
+	for _, table := range tables {
+		arn, err := createTable(ctx, client, buildCreateTableInput(table))
+		if err != nil {
+			return err
+		}
+
+		provisioned.Tables[table.Name] = arn
+	}
+
+	return nil
+}
+
+// createTable creates the table when it does not exist, waits for it to be active and returns its ARN
+func createTable(ctx context.Context, client *dynamodb.Client, input *dynamodb.CreateTableInput) (string, error) {
+	name := aws.ToString(input.TableName)
+
+	if _, err := client.CreateTable(ctx, input); err != nil {
+		var inUse *dynamotypes.ResourceInUseException
+		if !errors.As(err, &inUse) {
+			return "", fmt.Errorf("failed to create the table '%s': %w", name, err)
+		}
+	}
+
+	describeInput := &dynamodb.DescribeTableInput{
+		TableName: input.TableName,
+	}
+
+	if err := dynamodb.NewTableExistsWaiter(client).Wait(ctx, describeInput, 30*time.Second); err != nil {
+		return "", fmt.Errorf("failed to wait for the table '%s': %w", name, err)
+	}
+
+	output, err := client.DescribeTable(ctx, describeInput)
+	if err != nil {
+		return "", fmt.Errorf("failed to describe the table '%s': %w", name, err)
+	}
+
+	return aws.ToString(output.Table.TableArn), nil
+}
+
+func buildCreateTableInput(table Table) *dynamodb.CreateTableInput {
+	var definitions []dynamotypes.AttributeDefinition
+	seen := make(map[string]bool)
+
+	define := func(key Key) {
+		if seen[key.Name] {
+			return
+		}
+		seen[key.Name] = true
+
+		keyType := key.Type
+		if keyType == "" {
+			keyType = dynamotypes.ScalarAttributeTypeS
+		}
+
+		definitions = append(definitions, dynamotypes.AttributeDefinition{
+			AttributeName: aws.String(key.Name),
+			AttributeType: keyType,
+		})
+	}
+
+	keySchema := func(partitionKey Key, sortKey *Key) []dynamotypes.KeySchemaElement {
+		define(partitionKey)
+		schema := []dynamotypes.KeySchemaElement{
+			{AttributeName: aws.String(partitionKey.Name), KeyType: dynamotypes.KeyTypeHash},
+		}
+
+		if sortKey != nil {
+			define(*sortKey)
+			schema = append(schema, dynamotypes.KeySchemaElement{
+				AttributeName: aws.String(sortKey.Name),
+				KeyType:       dynamotypes.KeyTypeRange,
+			})
+		}
+
+		return schema
+	}
+
+	input := &dynamodb.CreateTableInput{
+		TableName:   aws.String(table.Name),
+		BillingMode: dynamotypes.BillingModePayPerRequest,
+		KeySchema:   keySchema(table.PartitionKey, table.SortKey),
+	}
+
+	for _, index := range table.Indexes {
+		input.GlobalSecondaryIndexes = append(input.GlobalSecondaryIndexes, dynamotypes.GlobalSecondaryIndex{
+			IndexName: aws.String(index.Name),
+			KeySchema: keySchema(index.PartitionKey, index.SortKey),
+			Projection: &dynamotypes.Projection{
+				ProjectionType: dynamotypes.ProjectionTypeAll,
+			},
+		})
+	}
+
+	input.AttributeDefinitions = definitions
+
+	return input
+}
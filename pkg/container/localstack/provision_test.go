@@ -0,0 +1,92 @@
+package localstack_test
+
+import (
+	"testing"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/jfelipearaujo/testcontainers/pkg/container/localstack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResources(t *testing.T) {
+	t.Run("Should accept the references to declared resources", func(t *testing.T) {
+		// Arrange
+		resources := localstack.Resources{
+			Queues: []localstack.Queue{
+				{Name: "orders", DeadLetterQueue: "orders-dlq"},
+				{Name: "orders-dlq"},
+			},
+			Topics: []localstack.Topic{
+				{Name: "events"},
+			},
+			Subscriptions: []localstack.Subscription{
+				{Topic: "events", Queue: "orders", FilterPolicy: `{"type": ["order_created"]}`},
+			},
+			Tables: []localstack.Table{
+				{Name: "orders", PartitionKey: localstack.Key{Name: "id"}},
+			},
+		}
+
+		// Act
+		err := resources.Validate()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, resources.IsEmpty())
+	})
+
+	t.Run("Should report the references to unknown resources", func(t *testing.T) {
+		// Arrange
+		resources := localstack.Resources{
+			Queues: []localstack.Queue{
+				{Name: "orders", DeadLetterQueue: "orders-dlq"},
+			},
+			Subscriptions: []localstack.Subscription{
+				{Topic: "events", Queue: "orders"},
+			},
+			Tables: []localstack.Table{
+				{Name: "orders"},
+			},
+		}
+
+		// Act
+		err := resources.Validate()
+
+		// Assert
+		assert.EqualError(t, err, "queue 'orders': dead-letter queue 'orders-dlq' is not declared\n"+
+			"subscription: topic 'events' is not declared\n"+
+			"table 'orders': the name and the partition key are required")
+	})
+
+	t.Run("Should be empty when no resource is declared", func(t *testing.T) {
+		// Assert
+		assert.True(t, localstack.Resources{}.IsEmpty())
+	})
+}
+
+func TestWithResources(t *testing.T) {
+	t.Run("Should provision the resources once the container is ready", func(t *testing.T) {
+		// Act
+		definition := container.NewContainerDefinition(
+			localstack.WithLocalStackContainer(
+				localstack.WithResources(localstack.Resources{
+					Queues: []localstack.Queue{{Name: "orders"}},
+				}),
+			),
+		)
+
+		// Assert
+		assert.Len(t, definition.ContainerRequest.LifecycleHooks, 1)
+		assert.Len(t, definition.ContainerRequest.LifecycleHooks[0].PostReadies, 1)
+	})
+
+	t.Run("Should not add a hook without resources", func(t *testing.T) {
+		// Act
+		definition := container.NewContainerDefinition(
+			localstack.WithLocalStackContainer(),
+		)
+
+		// Assert
+		assert.Empty(t, definition.ContainerRequest.LifecycleHooks)
+	})
+}
@@ -0,0 +1,129 @@
+package localstack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	ResetPath        string = "/_localstack/state/reset"
+	ServiceResetPath string = "/_localstack/state/%s/reset"
+)
+
+// Reset resets the state of the given services, or of all the services when none is given, of the LocalStack
+// container listening on the default exposed port
+//
+// Example:
+//
+//	err := localstack.Reset(ctx, localStackContainer, localstack.SQS, localstack.SNS)
+//
+// For a container started with WithExposedPort, resolve the endpoint with the same LocalStackOptions:
+//
+//	endpoint, err := localstack.BuildEndpoint(ctx, localStackContainer, localstack.WithExposedPort("4567"))
+//	...
+//	err = localstack.ResetEndpoint(ctx, endpoint, localstack.SQS, localstack.SNS)
+func Reset(ctx context.Context, container testcontainers.Container, services ...Service) error {
+	endpoint, err := BuildEndpoint(ctx, container)
+	if err != nil {
+		return err
+	}
+
+	return ResetEndpoint(ctx, endpoint, services...)
+}
+
+// ResetEndpoint is like Reset, for the LocalStack container listening on the given endpoint
+func ResetEndpoint(ctx context.Context, endpoint string, services ...Service) error {
+	paths := []string{ResetPath}
+	if len(services) > 0 {
+		paths = make([]string, len(services))
+		for i, service := range services {
+			paths[i] = fmt.Sprintf(ServiceResetPath, service)
+		}
+	}
+
+	for _, path := range paths {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+path, nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to reset the state: %w", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("failed to reset the state: unexpected status code %d from %s", resp.StatusCode, path)
+		}
+	}
+
+	return nil
+}
+
+// ResetAndProvision resets the state of the services set by WithServices, then seeds again the secrets and parameters
+// and provisions again the resources set by WithResources
+//
+// Example:
+//
+//	provisioned, err := localstack.ResetAndProvision(ctx, localStackContainer,
+//		localstack.WithServices(localstack.SQS),
+//		localstack.WithResources(resources),
+//	)
+func ResetAndProvision(ctx context.Context, container testcontainers.Container, opts ...LocalStackOption) (*Provisioned, error) {
+	options := buildOptions(opts...)
+
+	endpoint, err := reset(ctx, container, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := NewAWSConfig(endpoint, opts...)
+
+	seed, err := buildSeed(options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := SeedValues(ctx, cfg, seed); err != nil {
+		return nil, fmt.Errorf("failed to seed the values: %w", err)
+	}
+
+	return Provision(ctx, cfg, options.Resources)
+}
+
+// reset resets the state of the services set by WithServices and returns the endpoint of the container
+func reset(ctx context.Context, container testcontainers.Container, opts ...LocalStackOption) (string, error) {
+	options := buildOptions(opts...)
+
+	endpoint, err := BuildEndpoint(ctx, container, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	if err := ResetEndpoint(ctx, endpoint, options.Services...); err != nil {
+		return "", err
+	}
+
+	return endpoint, nil
+}
+
+// ResetHook returns a reset hook that calls ResetAndProvision, to be registered on a shared group of containers
+// with the same LocalStackOptions given to WithLocalStackContainer
+//
+// Example:
+//
+//	group := container.BuildGroupContainer(
+//		container.WithDockerContainer(localStackContainer),
+//		container.WithResetHook(localstack.ResetHook(localStackContainer, opts...)),
+//	)
+func ResetHook(target testcontainers.Container, opts ...LocalStackOption) container.ResetFunc {
+	return func(ctx context.Context) error {
+		_, err := ResetAndProvision(ctx, target, opts...)
+		return err
+	}
+}
@@ -0,0 +1,113 @@
+package localstack_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/jfelipearaujo/testcontainers/pkg/container/localstack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// portContainer is a started container mapping only the given exposed port to the given host address
+type portContainer struct {
+	testcontainers.Container
+	exposed string
+	address *url.URL
+}
+
+func (c portContainer) Host(context.Context) (string, error) {
+	return c.address.Hostname(), nil
+}
+
+func (c portContainer) MappedPort(_ context.Context, port nat.Port) (nat.Port, error) {
+	if port.Port() != c.exposed {
+		return "", fmt.Errorf("port %s not mapped", port)
+	}
+	return nat.Port(c.address.Port() + "/tcp"), nil
+}
+
+func TestResetEndpoint(t *testing.T) {
+	newServer := func(paths *[]string, status int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*paths = append(*paths, r.Method+" "+r.URL.Path)
+			w.WriteHeader(status)
+		}))
+	}
+
+	t.Run("Should reset all the services", func(t *testing.T) {
+		// Arrange
+		var paths []string
+		server := newServer(&paths, http.StatusOK)
+		defer server.Close()
+
+		// Act
+		err := localstack.ResetEndpoint(context.Background(), server.URL)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"POST /_localstack/state/reset"}, paths)
+	})
+
+	t.Run("Should reset the given services", func(t *testing.T) {
+		// Arrange
+		var paths []string
+		server := newServer(&paths, http.StatusOK)
+		defer server.Close()
+
+		// Act
+		err := localstack.ResetEndpoint(context.Background(), server.URL, localstack.SQS, localstack.SNS)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"POST /_localstack/state/sqs/reset",
+			"POST /_localstack/state/sns/reset",
+		}, paths)
+	})
+
+	t.Run("Should return an error when the reset fails", func(t *testing.T) {
+		// Arrange
+		var paths []string
+		server := newServer(&paths, http.StatusNotFound)
+		defer server.Close()
+
+		// Act
+		err := localstack.ResetEndpoint(context.Background(), server.URL)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestReset(t *testing.T) {
+	t.Run("Should reset the given services of the container listening on the default port", func(t *testing.T) {
+		// Arrange
+		var paths []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			paths = append(paths, r.Method+" "+r.URL.Path)
+		}))
+		defer server.Close()
+
+		address, err := url.Parse(server.URL)
+		require.NoError(t, err)
+
+		container := portContainer{exposed: localstack.ExposedPort, address: address}
+
+		// Act
+		err = localstack.Reset(context.Background(), container, localstack.SQS, localstack.SNS)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"POST /_localstack/state/sqs/reset",
+			"POST /_localstack/state/sns/reset",
+		}, paths)
+	})
+}
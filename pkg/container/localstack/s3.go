@@ -0,0 +1,92 @@
+package localstack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// UploadDir uploads the files of the given directory to the bucket, the keys being the paths relative to the directory
+// with the given prefix
+//
+// Example:
+//
+//	err := localstack.UploadDir(ctx, cfg, "documents", "./testdata/documents", "incoming/")
+func UploadDir(ctx context.Context, cfg aws.Config, bucket string, dir string, prefix string) error {
+	client := NewS3Client(cfg)
+
+	return filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		relative, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		key := path.Join(prefix, filepath.ToSlash(relative))
+
+		input := &s3.PutObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+			Body:   f,
+		}
+		if contentType := mime.TypeByExtension(filepath.Ext(file)); contentType != "" {
+			input.ContentType = aws.String(contentType)
+		}
+
+		if _, err := client.PutObject(ctx, input); err != nil {
+			return fmt.Errorf("failed to upload '%s' to '%s/%s': %w", file, bucket, key, err)
+		}
+
+		return nil
+	})
+}
+
+// ObjectExists returns true when the object exists in the bucket
+func ObjectExists(ctx context.Context, cfg aws.Config, bucket string, key string) (bool, error) {
+	_, err := NewS3Client(cfg).HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var notFound *s3types.NotFound
+		if errors.As(err, &notFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check the object '%s/%s': %w", bucket, key, err)
+	}
+
+	return true, nil
+}
+
+// GetObject returns the content of the object
+func GetObject(ctx context.Context, cfg aws.Config, bucket string, key string) ([]byte, error) {
+	output, err := NewS3Client(cfg).GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the object '%s/%s': %w", bucket, key, err)
+	}
+	defer output.Body.Close()
+
+	return io.ReadAll(output.Body)
+}
@@ -0,0 +1,260 @@
+package localstack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	secretstypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/testcontainers/testcontainers-go"
+	"gopkg.in/yaml.v3"
+)
+
+// Seed is a type that represents the Secrets Manager secrets and the SSM parameters to be seeded, indexed by name
+type Seed struct {
+	Secrets    map[string]string
+	Parameters map[string]string
+}
+
+// IsEmpty returns true when no secret nor parameter is declared
+func (s Seed) IsEmpty() bool {
+	return len(s.Secrets) == 0 && len(s.Parameters) == 0
+}
+
+func (s *Seed) merge(other Seed) {
+	if len(other.Secrets) > 0 && s.Secrets == nil {
+		s.Secrets = make(map[string]string, len(other.Secrets))
+	}
+	for name, value := range other.Secrets {
+		s.Secrets[name] = value
+	}
+
+	if len(other.Parameters) > 0 && s.Parameters == nil {
+		s.Parameters = make(map[string]string, len(other.Parameters))
+	}
+	for name, value := range other.Parameters {
+		s.Parameters[name] = value
+	}
+}
+
+// WithSecrets is a LocalStackOption that seeds the given Secrets Manager secrets once the LocalStack container is ready
+//
+//	Default: nil
+//
+// Example:
+//
+//	localstack.WithSecrets(map[string]string{
+//		"app/db": `{"user": "app", "password": "secret"}`,
+//	})
+func WithSecrets(secrets map[string]string) LocalStackOption {
+	return func(options *Options) {
+		options.Seed.merge(Seed{Secrets: secrets})
+	}
+}
+
+// WithParameters is a LocalStackOption that seeds the given SSM parameters once the LocalStack container is ready
+//
+//	Default: nil
+//
+// Example:
+//
+//	localstack.WithParameters(map[string]string{
+//		"/app/db/host": "postgres",
+//	})
+func WithParameters(parameters map[string]string) LocalStackOption {
+	return func(options *Options) {
+		options.Seed.merge(Seed{Parameters: parameters})
+	}
+}
+
+// WithSeedFiles is a LocalStackOption that seeds the secrets and parameters of the given YAML files once the LocalStack
+// container is ready, see ReadSeedFile
+//
+//	Default: nil
+func WithSeedFiles(files ...string) LocalStackOption {
+	return func(options *Options) {
+		options.SeedFiles = append(options.SeedFiles, files...)
+	}
+}
+
+// ReadSeedFile reads the secrets and parameters of a YAML file, the values that are not strings are encoded as JSON
+//
+//	secrets:
+//		app/db:
+//			user: app
+//			password: secret
+//	parameters:
+//		/app/db/host: postgres
+//		/app/db/port: 5432
+func ReadSeedFile(file string) (Seed, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return Seed{}, fmt.Errorf("failed to read the seed file '%s': %w", file, err)
+	}
+
+	var values struct {
+		Secrets    map[string]any `yaml:"secrets"`
+		Parameters map[string]any `yaml:"parameters"`
+	}
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return Seed{}, fmt.Errorf("failed to parse the seed file '%s': %w", file, err)
+	}
+
+	secrets, err := stringValues(values.Secrets)
+	if err != nil {
+		return Seed{}, fmt.Errorf("invalid secret in the seed file '%s': %w", file, err)
+	}
+
+	parameters, err := stringValues(values.Parameters)
+	if err != nil {
+		return Seed{}, fmt.Errorf("invalid parameter in the seed file '%s': %w", file, err)
+	}
+
+	return Seed{
+		Secrets:    secrets,
+		Parameters: parameters,
+	}, nil
+}
+
+// SeedValues creates or updates the given secrets and parameters
+//
+// Example:
+//
+//	err := localstack.SeedValues(ctx, cfg, localstack.Seed{
+//		Parameters: map[string]string{"/app/feature/enabled": "true"},
+//	})
+func SeedValues(ctx context.Context, cfg aws.Config, seed Seed) error {
+	if len(seed.Secrets) > 0 {
+		client := secretsmanager.NewFromConfig(cfg)
+
+		for _, name := range sortedKeys(seed.Secrets) {
+			if err := putSecret(ctx, client, name, seed.Secrets[name]); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(seed.Parameters) > 0 {
+		client := ssm.NewFromConfig(cfg)
+
+		for _, name := range sortedKeys(seed.Parameters) {
+			_, err := client.PutParameter(ctx, &ssm.PutParameterInput{
+				Name:      aws.String(name),
+				Value:     aws.String(seed.Parameters[name]),
+				Type:      ssmtypes.ParameterTypeString,
+				Overwrite: aws.Bool(true),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to put the parameter '%s': %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func withSeeding(c *container.Container, opts ...LocalStackOption) {
+	options := buildOptions(opts...)
+
+	c.ContainerRequest.LifecycleHooks = append(c.ContainerRequest.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+		PostReadies: []testcontainers.ContainerHook{
+			func(ctx context.Context, target testcontainers.Container) error {
+				seed, err := buildSeed(options)
+				if err != nil {
+					return err
+				}
+
+				cfg, err := AWSConfig(ctx, target, opts...)
+				if err != nil {
+					return err
+				}
+
+				if err := SeedValues(ctx, cfg, seed); err != nil {
+					return fmt.Errorf("failed to seed the values: %w", err)
+				}
+
+				return nil
+			},
+		},
+	})
+}
+
+// buildSeed returns the values set by WithSecrets and WithParameters, merged with the values of the seed files
+func buildSeed(options *Options) (Seed, error) {
+	seed := Seed{}
+	seed.merge(options.Seed)
+
+	for _, file := range options.SeedFiles {
+		values, err := ReadSeedFile(file)
+		if err != nil {
+			return Seed{}, err
+		}
+		seed.merge(values)
+	}
+
+	return seed, nil
+}
+
+func putSecret(ctx context.Context, client *secretsmanager.Client, name string, value string) error {
+	_, err := client.CreateSecret(ctx, &secretsmanager.CreateSecretInput{
+		Name:         aws.String(name),
+		SecretString: aws.String(value),
+	})
+	if err == nil {
+		return nil
+	}
+
+	var exists *secretstypes.ResourceExistsException
+	if !errors.As(err, &exists) {
+		return fmt.Errorf("failed to create the secret '%s': %w", name, err)
+	}
+
+	_, err = client.PutSecretValue(ctx, &secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String(name),
+		SecretString: aws.String(value),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update the secret '%s': %w", name, err)
+	}
+
+	return nil
+}
+
+func stringValues(values map[string]any) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	output := make(map[string]string, len(values))
+	for name, value := range values {
+		switch v := value.(type) {
+		case string:
+			output[name] = v
+		default:
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("'%s': %w", name, err)
+			}
+			output[name] = string(encoded)
+		}
+	}
+
+	return output, nil
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
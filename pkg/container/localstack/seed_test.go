@@ -0,0 +1,66 @@
+package localstack_test
+
+import (
+	"testing"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/jfelipearaujo/testcontainers/pkg/container/localstack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSeedFile(t *testing.T) {
+	t.Run("Should read the secrets and parameters", func(t *testing.T) {
+		// Arrange
+		file := writeFile(t, "seed.yaml", `
+secrets:
+  app/api-key: key
+  app/db:
+    user: app
+    password: secret
+parameters:
+  /app/db/host: postgres
+  /app/db/port: 5432
+`)
+
+		// Act
+		seed, err := localstack.ReadSeedFile(file)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"app/api-key": "key",
+			"app/db":      `{"password":"secret","user":"app"}`,
+		}, seed.Secrets)
+		assert.Equal(t, map[string]string{
+			"/app/db/host": "postgres",
+			"/app/db/port": "5432",
+		}, seed.Parameters)
+	})
+
+	t.Run("Should return an error when the file is not YAML", func(t *testing.T) {
+		// Arrange
+		file := writeFile(t, "seed.yaml", "secrets: [")
+
+		// Act
+		_, err := localstack.ReadSeedFile(file)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestWithSecrets(t *testing.T) {
+	t.Run("Should seed the values once the container is ready", func(t *testing.T) {
+		// Act
+		definition := container.NewContainerDefinition(
+			localstack.WithLocalStackContainer(
+				localstack.WithSecrets(map[string]string{"app/api-key": "key"}),
+				localstack.WithParameters(map[string]string{"/app/db/host": "postgres"}),
+			),
+		)
+
+		// Assert
+		assert.Len(t, definition.ContainerRequest.LifecycleHooks, 1)
+	})
+}
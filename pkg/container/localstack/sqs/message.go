@@ -0,0 +1,136 @@
+package sqs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/jfelipearaujo/testcontainers/pkg/internal/subset"
+)
+
+// Message is a type that represents a received SQS message
+//
+// When the message was delivered by an SNS subscription without raw message delivery, the envelope is unwrapped:
+// Body is the published message, Attributes are the published message attributes and TopicArn is set
+type Message struct {
+	ID            string
+	ReceiptHandle string
+	Body          string
+	Attributes    map[string]string
+	TopicArn      string
+}
+
+// snsEnvelope is the body of the SQS messages delivered by SNS without raw message delivery
+type snsEnvelope struct {
+	Type              string `json:"Type"`
+	TopicArn          string `json:"TopicArn"`
+	Message           string `json:"Message"`
+	MessageAttributes map[string]struct {
+		Type  string `json:"Type"`
+		Value string `json:"Value"`
+	} `json:"MessageAttributes"`
+}
+
+// ParseMessage converts the given SQS message, unwrapping the SNS envelope
+func ParseMessage(message types.Message) Message {
+	output := Message{
+		ID:            valueOf(message.MessageId),
+		ReceiptHandle: valueOf(message.ReceiptHandle),
+		Body:          valueOf(message.Body),
+		Attributes:    make(map[string]string, len(message.MessageAttributes)),
+	}
+
+	for name, attribute := range message.MessageAttributes {
+		output.Attributes[name] = valueOf(attribute.StringValue)
+	}
+
+	var envelope snsEnvelope
+	if err := json.Unmarshal([]byte(output.Body), &envelope); err != nil {
+		return output
+	}
+	if envelope.Type != "Notification" || envelope.TopicArn == "" {
+		return output
+	}
+
+	output.Body = envelope.Message
+	output.TopicArn = envelope.TopicArn
+	for name, attribute := range envelope.MessageAttributes {
+		output.Attributes[name] = attribute.Value
+	}
+
+	return output
+}
+
+// Matcher is a type that represents a message matcher, returning the reasons why the message does not match
+type Matcher func(message Message) []string
+
+// MatchJSON is a Matcher that checks that the body of the message contains the expected JSON, see subset.Diff
+//
+// Example:
+//
+//	sqs.MatchJSON(`{"type": "order_created", "order": {"id": "123"}}`, "createdAt")
+func MatchJSON(expected string, ignore ...string) Matcher {
+	return func(message Message) []string {
+		diffs, err := subset.DiffJSON([]byte(expected), []byte(message.Body), ignore...)
+		if err != nil {
+			return []string{err.Error()}
+		}
+		return diffs
+	}
+}
+
+// MatchAttributes is a Matcher that checks the message attributes
+//
+// Example:
+//
+//	sqs.MatchAttributes(map[string]string{"eventType": "order_created"})
+func MatchAttributes(expected map[string]string) Matcher {
+	return func(message Message) []string {
+		var diffs []string
+		for name, value := range expected {
+			actual, ok := message.Attributes[name]
+			if !ok {
+				diffs = append(diffs, fmt.Sprintf("attribute %s: expected %q, got nothing", name, value))
+				continue
+			}
+			if actual != value {
+				diffs = append(diffs, fmt.Sprintf("attribute %s: expected %q, got %q", name, value, actual))
+			}
+		}
+		return diffs
+	}
+}
+
+// Match returns the reasons why the message does not match all the given matchers
+func Match(message Message, matchers ...Matcher) []string {
+	var diffs []string
+	for _, matcher := range matchers {
+		diffs = append(diffs, matcher(message)...)
+	}
+	return diffs
+}
+
+// MismatchError is the error returned when the expected messages are not received before the timeout
+type MismatchError struct {
+	QueueURL string
+	Expected int
+	Received int
+	Closest  [][]string
+}
+
+func (e *MismatchError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "expected %d matching messages on the queue '%s', but got %d", e.Expected, e.QueueURL, e.Received)
+	for i, diffs := range e.Closest {
+		fmt.Fprintf(&sb, "\n  closest message %d:%s", i+1, subset.Format(diffs))
+	}
+	return sb.String()
+}
+
+func valueOf(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
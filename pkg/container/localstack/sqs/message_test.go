@@ -0,0 +1,93 @@
+package sqs_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/jfelipearaujo/testcontainers/pkg/container/localstack/sqs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMessage(t *testing.T) {
+	t.Run("Should keep the body of a raw message", func(t *testing.T) {
+		// Arrange
+		message := types.Message{
+			MessageId: aws.String("1"),
+			Body:      aws.String(`{"type": "order_created"}`),
+			MessageAttributes: map[string]types.MessageAttributeValue{
+				"eventType": {DataType: aws.String("String"), StringValue: aws.String("order_created")},
+			},
+		}
+
+		// Act
+		output := sqs.ParseMessage(message)
+
+		// Assert
+		assert.Equal(t, "1", output.ID)
+		assert.Equal(t, `{"type": "order_created"}`, output.Body)
+		assert.Equal(t, map[string]string{"eventType": "order_created"}, output.Attributes)
+		assert.Empty(t, output.TopicArn)
+	})
+
+	t.Run("Should unwrap the SNS envelope", func(t *testing.T) {
+		// Arrange
+		message := types.Message{
+			MessageId: aws.String("1"),
+			Body: aws.String(`{
+				"Type": "Notification",
+				"TopicArn": "arn:aws:sns:us-east-1:000000000000:events",
+				"Message": "{\"type\": \"order_created\"}",
+				"MessageAttributes": {"eventType": {"Type": "String", "Value": "order_created"}}
+			}`),
+		}
+
+		// Act
+		output := sqs.ParseMessage(message)
+
+		// Assert
+		assert.Equal(t, `{"type": "order_created"}`, output.Body)
+		assert.Equal(t, map[string]string{"eventType": "order_created"}, output.Attributes)
+		assert.Equal(t, "arn:aws:sns:us-east-1:000000000000:events", output.TopicArn)
+	})
+}
+
+func TestMatch(t *testing.T) {
+	message := sqs.Message{
+		Body:       `{"type": "order_created", "order": {"id": "123", "total": 10}}`,
+		Attributes: map[string]string{"eventType": "order_created"},
+	}
+
+	t.Run("Should match the JSON subset and the attributes", func(t *testing.T) {
+		// Act
+		diffs := sqs.Match(message,
+			sqs.MatchJSON(`{"order": {"id": "123"}}`),
+			sqs.MatchAttributes(map[string]string{"eventType": "order_created"}),
+		)
+
+		// Assert
+		assert.Empty(t, diffs)
+	})
+
+	t.Run("Should report the differences", func(t *testing.T) {
+		// Act
+		diffs := sqs.Match(message,
+			sqs.MatchJSON(`{"order": {"id": "456"}}`),
+			sqs.MatchAttributes(map[string]string{"source": "api"}),
+		)
+
+		// Assert
+		assert.Equal(t, []string{
+			`$.order.id: expected "456", got "123"`,
+			`attribute source: expected "api", got nothing`,
+		}, diffs)
+	})
+
+	t.Run("Should not match a body that is not JSON", func(t *testing.T) {
+		// Act
+		diffs := sqs.Match(sqs.Message{Body: "Hello World!"}, sqs.MatchJSON(`{}`))
+
+		// Assert
+		assert.Len(t, diffs, 1)
+	})
+}
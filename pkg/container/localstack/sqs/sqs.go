@@ -0,0 +1,248 @@
+package sqs
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+const (
+	// maxReportedMessages is the number of closest messages reported when the expected messages are not received
+	maxReportedMessages int   = 3
+	maxMessages         int32 = 10
+	waitTimeSeconds     int32 = 1
+)
+
+// Client is a type that represents the SQS assertion helpers
+type Client struct {
+	SQS *awssqs.Client
+}
+
+// New returns the SQS assertion helpers for the given config
+//
+// Example:
+//
+//	cfg, err := localstack.AWSConfig(ctx, localStackContainer)
+//	if err != nil {
+//		return ctx, err
+//	}
+//
+//	client := sqs.New(cfg)
+func New(cfg aws.Config) *Client {
+	return &Client{
+		SQS: awssqs.NewFromConfig(cfg),
+	}
+}
+
+// QueueURL returns the URL of the given queue
+func (c *Client) QueueURL(ctx context.Context, name string) (string, error) {
+	output, err := c.SQS.GetQueueUrl(ctx, &awssqs.GetQueueUrlInput{
+		QueueName: aws.String(name),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get the URL of the queue '%s': %w", name, err)
+	}
+
+	return *output.QueueUrl, nil
+}
+
+// AwaitMessages polls the queue until n messages matching all the given matchers are received, or returns
+// a MismatchError when the timeout is reached
+//
+// The first n matching messages are deleted from the queue and returned. The other ones, including the matching
+// messages received beyond n, are kept invisible until the wait ends and are then made visible again, so each
+// message is received once per wait. Every receive still increases the ApproximateReceiveCount of the message,
+// so with a low maxReceiveCount the next receive of the application may move it to the dead-letter queue.
+//
+// Example:
+//
+//	messages, err := client.AwaitMessages(ctx, queueURL, 1, 10*time.Second,
+//		sqs.MatchJSON(`{"type": "order_created"}`),
+//	)
+func (c *Client) AwaitMessages(ctx context.Context, queueURL string, n int, timeout time.Duration, matchers ...Matcher) (matched []Message, err error) {
+	parent := ctx
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// the inspected messages stay invisible for the whole wait, and a bit more for the last receive
+	visibility := int32(math.Ceil(timeout.Seconds())) + waitTimeSeconds + 1
+
+	var inspected []Message
+	defer func() {
+		if releaseErr := c.releaseAll(context.WithoutCancel(parent), queueURL, inspected); releaseErr != nil && err == nil {
+			err = releaseErr
+		}
+	}()
+
+	mismatches := make(map[string][]string)
+
+	for len(matched) < n {
+		received, err := c.receive(ctx, queueURL, waitTimeSeconds, visibility)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return matched, err
+		}
+
+		for _, message := range received {
+			diffs := Match(message, matchers...)
+			if len(diffs) > 0 {
+				mismatches[message.ID] = diffs
+				inspected = append(inspected, message)
+				continue
+			}
+
+			if len(matched) == n {
+				inspected = append(inspected, message)
+				continue
+			}
+
+			delete(mismatches, message.ID)
+			matched = append(matched, message)
+			if err := c.delete(ctx, queueURL, message); err != nil && ctx.Err() == nil {
+				return matched, err
+			}
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	if len(matched) >= n {
+		return matched, nil
+	}
+
+	closest := make([][]string, 0, len(mismatches))
+	for _, diffs := range mismatches {
+		closest = append(closest, diffs)
+	}
+	sort.SliceStable(closest, func(i, j int) bool {
+		return len(closest[i]) < len(closest[j])
+	})
+
+	return matched, &MismatchError{
+		QueueURL: queueURL,
+		Expected: n,
+		Received: len(matched),
+		Closest:  closest[:min(len(closest), maxReportedMessages)],
+	}
+}
+
+// Count returns the approximate number of messages of the queue, the visible, in-flight and delayed ones
+func (c *Client) Count(ctx context.Context, queueURL string) (int, error) {
+	output, err := c.SQS.GetQueueAttributes(ctx, &awssqs.GetQueueAttributesInput{
+		QueueUrl: aws.String(queueURL),
+		AttributeNames: []types.QueueAttributeName{
+			types.QueueAttributeNameApproximateNumberOfMessages,
+			types.QueueAttributeNameApproximateNumberOfMessagesNotVisible,
+			types.QueueAttributeNameApproximateNumberOfMessagesDelayed,
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the attributes of the queue '%s': %w", queueURL, err)
+	}
+
+	var count int
+	for _, value := range output.Attributes {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid message count '%s' for the queue '%s': %w", value, queueURL, err)
+		}
+		count += n
+	}
+
+	return count, nil
+}
+
+// Drain receives and deletes all the visible messages of the queue
+func (c *Client) Drain(ctx context.Context, queueURL string) ([]Message, error) {
+	var drained []Message
+
+	for {
+		received, err := c.receive(ctx, queueURL, 0, 0)
+		if err != nil {
+			return drained, err
+		}
+		if len(received) == 0 {
+			return drained, nil
+		}
+
+		for _, message := range received {
+			if err := c.delete(ctx, queueURL, message); err != nil {
+				return drained, err
+			}
+			drained = append(drained, message)
+		}
+	}
+}
+
+// Purge deletes all the messages of the queue, including the in-flight ones
+func (c *Client) Purge(ctx context.Context, queueURL string) error {
+	_, err := c.SQS.PurgeQueue(ctx, &awssqs.PurgeQueueInput{
+		QueueUrl: aws.String(queueURL),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to purge the queue '%s': %w", queueURL, err)
+	}
+
+	return nil
+}
+
+// receive receives the visible messages of the queue, the visibility timeout of the queue being used when zero
+func (c *Client) receive(ctx context.Context, queueURL string, wait int32, visibility int32) ([]Message, error) {
+	output, err := c.SQS.ReceiveMessage(ctx, &awssqs.ReceiveMessageInput{
+		QueueUrl:              aws.String(queueURL),
+		MaxNumberOfMessages:   maxMessages,
+		WaitTimeSeconds:       wait,
+		VisibilityTimeout:     visibility,
+		MessageAttributeNames: []string{"All"},
+		AttributeNames:        []types.QueueAttributeName{types.QueueAttributeNameAll},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to receive the messages of '%s': %w", queueURL, err)
+	}
+
+	messages := make([]Message, len(output.Messages))
+	for i, message := range output.Messages {
+		messages[i] = ParseMessage(message)
+	}
+
+	return messages, nil
+}
+
+func (c *Client) delete(ctx context.Context, queueURL string, message Message) error {
+	_, err := c.SQS.DeleteMessage(ctx, &awssqs.DeleteMessageInput{
+		QueueUrl:      aws.String(queueURL),
+		ReceiptHandle: aws.String(message.ReceiptHandle),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete the message '%s': %w", message.ID, err)
+	}
+
+	return nil
+}
+
+// releaseAll makes the given messages visible again
+func (c *Client) releaseAll(ctx context.Context, queueURL string, messages []Message) error {
+	for _, message := range messages {
+		_, err := c.SQS.ChangeMessageVisibility(ctx, &awssqs.ChangeMessageVisibilityInput{
+			QueueUrl:          aws.String(queueURL),
+			ReceiptHandle:     aws.String(message.ReceiptHandle),
+			VisibilityTimeout: 0,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to release the message '%s': %w", message.ID, err)
+		}
+	}
+
+	return nil
+}
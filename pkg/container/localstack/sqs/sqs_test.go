@@ -0,0 +1,223 @@
+package sqs_test
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cucumber/godog"
+	"github.com/jfelipearaujo/testcontainers/pkg/container/localstack"
+	"github.com/jfelipearaujo/testcontainers/pkg/container/localstack/sqs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMessage is a message of the fake queue
+type fakeMessage struct {
+	id       string
+	body     string
+	visible  bool
+	deleted  bool
+	receives int
+}
+
+// fakeQueue is a single SQS queue answering the JSON protocol of the SDK
+type fakeQueue struct {
+	mu       sync.Mutex
+	messages []*fakeMessage
+}
+
+func newFakeQueue(t *testing.T, bodies ...string) (*fakeQueue, *sqs.Client) {
+	queue := &fakeQueue{}
+	for i, body := range bodies {
+		queue.messages = append(queue.messages, &fakeMessage{id: strconv.Itoa(i + 1), body: body, visible: true})
+	}
+
+	server := httptest.NewServer(queue)
+	t.Cleanup(server.Close)
+
+	return queue, sqs.New(localstack.NewAWSConfig(server.URL))
+}
+
+func (q *fakeQueue) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var input struct {
+		ReceiptHandle string
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var output any
+	switch strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "AmazonSQS.") {
+	case "GetQueueUrl":
+		output = map[string]string{"QueueUrl": "http://localhost/000000000000/orders"}
+	case "ReceiveMessage":
+		var messages []map[string]string
+		for _, message := range q.messages {
+			if message.visible && !message.deleted && len(messages) < 10 {
+				message.visible = false
+				message.receives++
+				sum := md5.Sum([]byte(message.body))
+				messages = append(messages, map[string]string{
+					"MessageId":     message.id,
+					"ReceiptHandle": message.id,
+					"Body":          message.body,
+					"MD5OfBody":     hex.EncodeToString(sum[:]),
+				})
+			}
+		}
+		output = map[string]any{"Messages": messages}
+	case "DeleteMessage":
+		q.find(input.ReceiptHandle).deleted = true
+		output = map[string]any{}
+	case "ChangeMessageVisibility":
+		q.find(input.ReceiptHandle).visible = true
+		output = map[string]any{}
+	case "GetQueueAttributes":
+		var visible, inFlight int
+		for _, message := range q.messages {
+			switch {
+			case message.deleted:
+			case message.visible:
+				visible++
+			default:
+				inFlight++
+			}
+		}
+		output = map[string]any{"Attributes": map[string]string{
+			"ApproximateNumberOfMessages":           strconv.Itoa(visible),
+			"ApproximateNumberOfMessagesNotVisible": strconv.Itoa(inFlight),
+			"ApproximateNumberOfMessagesDelayed":    "0",
+		}}
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+	json.NewEncoder(w).Encode(output)
+}
+
+func (q *fakeQueue) find(receiptHandle string) *fakeMessage {
+	for _, message := range q.messages {
+		if message.id == receiptHandle {
+			return message
+		}
+	}
+	return &fakeMessage{}
+}
+
+// left returns the bodies of the messages not deleted, and whether they are all visible
+func (q *fakeQueue) left() ([]string, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var bodies []string
+	visible := true
+	for _, message := range q.messages {
+		if !message.deleted {
+			bodies = append(bodies, message.body)
+			visible = visible && message.visible
+		}
+	}
+	return bodies, visible
+}
+
+func TestAwaitMessages(t *testing.T) {
+	t.Run("Should delete only the expected number of matching messages and release the others", func(t *testing.T) {
+		// Arrange
+		queue, client := newFakeQueue(t,
+			`{"type": "order_created", "id": 1}`,
+			`{"type": "order_paid", "id": 1}`,
+			`{"type": "order_created", "id": 2}`,
+			`{"type": "order_created", "id": 3}`,
+		)
+
+		// Act
+		messages, err := client.AwaitMessages(context.Background(), "orders", 1, time.Second,
+			sqs.MatchJSON(`{"type": "order_created"}`),
+		)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, `{"type": "order_created", "id": 1}`, messages[0].Body)
+
+		left, visible := queue.left()
+		assert.Equal(t, []string{
+			`{"type": "order_paid", "id": 1}`,
+			`{"type": "order_created", "id": 2}`,
+			`{"type": "order_created", "id": 3}`,
+		}, left)
+		assert.True(t, visible)
+	})
+}
+
+func TestQueueShouldBeEmpty(t *testing.T) {
+	run := func(client *sqs.Client) (int, string) {
+		output := &strings.Builder{}
+
+		suite := godog.TestSuite{
+			ScenarioInitializer: func(ctx *godog.ScenarioContext) {
+				sqs.RegisterSteps(ctx, func(ctx context.Context) (*sqs.Client, error) {
+					return client, nil
+				}, sqs.WithTimeout(500*time.Millisecond))
+			},
+			Options: &godog.Options{
+				Format: "progress",
+				Output: output,
+				Strict: true,
+				FeatureContents: []godog.Feature{{
+					Name: "orders.feature",
+					Contents: []byte(`
+Feature: orders
+  Scenario: empty
+    Then the queue "orders" should be empty
+`),
+				}},
+			},
+		}
+
+		return suite.Run(), output.String()
+	}
+
+	t.Run("Should pass on an empty queue", func(t *testing.T) {
+		// Arrange
+		_, client := newFakeQueue(t)
+
+		// Act
+		status, output := run(client)
+
+		// Assert
+		assert.Zero(t, status, output)
+	})
+
+	t.Run("Should fail without consuming the in-flight messages", func(t *testing.T) {
+		// Arrange
+		queue, client := newFakeQueue(t, `{"type": "order_created"}`)
+		queue.messages[0].visible = false
+
+		// Act
+		status, output := run(client)
+
+		// Assert
+		assert.NotZero(t, status)
+		assert.Contains(t, output, "expected the queue 'orders' to be empty, but it still has 1 messages")
+
+		left, _ := queue.left()
+		assert.Equal(t, []string{`{"type": "order_created"}`}, left)
+		assert.Zero(t, queue.messages[0].receives)
+	})
+}
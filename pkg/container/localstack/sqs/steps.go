@@ -0,0 +1,149 @@
+package sqs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cucumber/godog"
+)
+
+// Timeout is the default time to wait for the expected messages
+const Timeout time.Duration = 10 * time.Second
+
+// pollInterval is the time between two reads of the message count of a queue
+const pollInterval time.Duration = 200 * time.Millisecond
+
+// Steps is a type that represents the queue steps of a LocalStack container
+type Steps struct {
+	Client  func(ctx context.Context) (*Client, error)
+	Timeout time.Duration
+}
+
+// StepsOption is a type that represents a Steps option
+type StepsOption func(*Steps)
+
+// WithTimeout is a StepsOption that sets the time to wait for the expected messages
+//
+// Default: 10 seconds
+func WithTimeout(timeout time.Duration) StepsOption {
+	return func(steps *Steps) {
+		steps.Timeout = timeout
+	}
+}
+
+// RegisterSteps registers the queue steps on the given scenario context
+//
+// The client function must return the client of the current scenario, the queues are referenced by name.
+// The registered steps are:
+//
+//	Given the queue "orders" is purged
+//	Then the queue "orders" should receive 1 message
+//	Then the queue "orders" should receive 1 message matching:
+//	Then the queue "orders" should receive 1 message with attributes:
+//	Then the queue "orders" should be empty
+//
+// The receive steps consume the given number of matching messages, the matching messages received beyond it being
+// left on the queue for the next steps. The empty step waits until the queue has no visible, in-flight or delayed message,
+// without receiving them. The messages are matched as a JSON subset of the bodies, after unwrapping the SNS envelopes,
+// and the attributes are given as a "| name | value |" table
+func RegisterSteps(ctx *godog.ScenarioContext, client func(ctx context.Context) (*Client, error), opts ...StepsOption) *Steps {
+	steps := &Steps{
+		Client:  client,
+		Timeout: Timeout,
+	}
+
+	for _, opt := range opts {
+		opt(steps)
+	}
+
+	ctx.Step(`^the queue "([^"]*)" is purged$`, steps.theQueueIsPurged)
+	ctx.Step(`^the queue "([^"]*)" should receive (\d+) messages?$`, steps.theQueueShouldReceiveMessages)
+	ctx.Step(`^the queue "([^"]*)" should receive (\d+) messages? matching:$`, steps.theQueueShouldReceiveMessagesMatching)
+	ctx.Step(`^the queue "([^"]*)" should receive (\d+) messages? with attributes:$`, steps.theQueueShouldReceiveMessagesWithAttributes)
+	ctx.Step(`^the queue "([^"]*)" should be empty$`, steps.theQueueShouldBeEmpty)
+
+	return steps
+}
+
+func (steps *Steps) theQueueIsPurged(ctx context.Context, name string) (context.Context, error) {
+	client, queueURL, err := steps.queue(ctx, name)
+	if err != nil {
+		return ctx, err
+	}
+
+	return ctx, client.Purge(ctx, queueURL)
+}
+
+func (steps *Steps) theQueueShouldReceiveMessages(ctx context.Context, name string, n int) (context.Context, error) {
+	return ctx, steps.await(ctx, name, n)
+}
+
+func (steps *Steps) theQueueShouldReceiveMessagesMatching(ctx context.Context, name string, n int, body *godog.DocString) (context.Context, error) {
+	return ctx, steps.await(ctx, name, n, MatchJSON(body.Content))
+}
+
+func (steps *Steps) theQueueShouldReceiveMessagesWithAttributes(ctx context.Context, name string, n int, table *godog.Table) (context.Context, error) {
+	attributes := make(map[string]string, len(table.Rows))
+	for _, row := range table.Rows {
+		if len(row.Cells) != 2 {
+			return ctx, fmt.Errorf("the attributes table must have 2 columns: name and value")
+		}
+		attributes[row.Cells[0].Value] = row.Cells[1].Value
+	}
+
+	return ctx, steps.await(ctx, name, n, MatchAttributes(attributes))
+}
+
+func (steps *Steps) theQueueShouldBeEmpty(ctx context.Context, name string) (context.Context, error) {
+	client, queueURL, err := steps.queue(ctx, name)
+	if err != nil {
+		return ctx, err
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, steps.Timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		count, err := client.Count(waitCtx, queueURL)
+		if err != nil {
+			return ctx, err
+		}
+		if count == 0 {
+			return ctx, nil
+		}
+
+		select {
+		case <-waitCtx.Done():
+			return ctx, fmt.Errorf("expected the queue '%s' to be empty, but it still has %d messages", name, count)
+		case <-ticker.C:
+		}
+	}
+}
+
+func (steps *Steps) await(ctx context.Context, name string, n int, matchers ...Matcher) error {
+	client, queueURL, err := steps.queue(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.AwaitMessages(ctx, queueURL, n, steps.Timeout, matchers...)
+	return err
+}
+
+func (steps *Steps) queue(ctx context.Context, name string) (*Client, string, error) {
+	client, err := steps.Client(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	queueURL, err := client.QueueURL(ctx, name)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return client, queueURL, nil
+}
@@ -0,0 +1,173 @@
+package localstack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/cucumber/godog"
+	"github.com/jfelipearaujo/testcontainers/pkg/internal/subset"
+)
+
+// Steps is a type that represents the S3 and DynamoDB steps of a LocalStack container
+type Steps struct {
+	Config func(ctx context.Context) (aws.Config, error)
+}
+
+// RegisterSteps registers the S3 and DynamoDB steps on the given scenario context
+//
+// The config function must return the AWS config of the current scenario. The registered steps are:
+//
+//	Given the directory "./testdata/documents" is uploaded to the bucket "documents"
+//	Then the bucket "documents" should contain the object "invoices/1.pdf"
+//	Then the object "reports/1.json" of the bucket "documents" should contain:
+//	Given the table schema "./testdata/orders.table.json" is created
+//	Given the items in "./testdata/orders.json" are loaded into the table "orders"
+//	Then the table "orders" should contain 2 items
+//	Then the table "orders" should contain an item matching:
+//
+// The JSON objects and items are matched as a subset, the other objects are compared as trimmed text
+func RegisterSteps(ctx *godog.ScenarioContext, config func(ctx context.Context) (aws.Config, error)) *Steps {
+	steps := &Steps{
+		Config: config,
+	}
+
+	ctx.Step(`^the directory "([^"]*)" is uploaded to the bucket "([^"]*)"$`, steps.theDirectoryIsUploadedToTheBucket)
+	ctx.Step(`^the bucket "([^"]*)" should contain the object "([^"]*)"$`, steps.theBucketShouldContainTheObject)
+	ctx.Step(`^the object "([^"]*)" of the bucket "([^"]*)" should contain:$`, steps.theObjectOfTheBucketShouldContain)
+	ctx.Step(`^the table schema "([^"]*)" is created$`, steps.theTableSchemaIsCreated)
+	ctx.Step(`^the items in "([^"]*)" are loaded into the table "([^"]*)"$`, steps.theItemsAreLoadedIntoTheTable)
+	ctx.Step(`^the table "([^"]*)" should contain (\d+) items?$`, steps.theTableShouldContainItems)
+	ctx.Step(`^the table "([^"]*)" should contain an item matching:$`, steps.theTableShouldContainAnItemMatching)
+
+	return steps
+}
+
+func (steps *Steps) theDirectoryIsUploadedToTheBucket(ctx context.Context, dir string, bucket string) (context.Context, error) {
+	cfg, err := steps.Config(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	return ctx, UploadDir(ctx, cfg, bucket, dir, "")
+}
+
+func (steps *Steps) theBucketShouldContainTheObject(ctx context.Context, bucket string, key string) (context.Context, error) {
+	cfg, err := steps.Config(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	exists, err := ObjectExists(ctx, cfg, bucket, key)
+	if err != nil {
+		return ctx, err
+	}
+
+	if !exists {
+		return ctx, fmt.Errorf("expected the object '%s' in the bucket '%s', but it does not exist", key, bucket)
+	}
+
+	return ctx, nil
+}
+
+func (steps *Steps) theObjectOfTheBucketShouldContain(ctx context.Context, key string, bucket string, expected *godog.DocString) (context.Context, error) {
+	cfg, err := steps.Config(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	content, err := GetObject(ctx, cfg, bucket, key)
+	if err != nil {
+		return ctx, err
+	}
+
+	if json.Valid([]byte(expected.Content)) {
+		diffs, err := subset.DiffJSON([]byte(expected.Content), content)
+		if err != nil {
+			return ctx, fmt.Errorf("the object '%s' of the bucket '%s' is not JSON: %w", key, bucket, err)
+		}
+		if len(diffs) > 0 {
+			return ctx, fmt.Errorf("the object '%s' of the bucket '%s' does not match:%s", key, bucket, subset.Format(diffs))
+		}
+		return ctx, nil
+	}
+
+	if !bytes.Equal(bytes.TrimSpace(content), []byte(strings.TrimSpace(expected.Content))) {
+		return ctx, fmt.Errorf("expected the object '%s' of the bucket '%s' to contain:\n%s\nbut got:\n%s", key, bucket, expected.Content, content)
+	}
+
+	return ctx, nil
+}
+
+func (steps *Steps) theTableSchemaIsCreated(ctx context.Context, file string) (context.Context, error) {
+	cfg, err := steps.Config(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	return ctx, CreateTables(ctx, cfg, file)
+}
+
+func (steps *Steps) theItemsAreLoadedIntoTheTable(ctx context.Context, file string, table string) (context.Context, error) {
+	cfg, err := steps.Config(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	return ctx, LoadItems(ctx, cfg, table, file)
+}
+
+func (steps *Steps) theTableShouldContainItems(ctx context.Context, table string, expected int) (context.Context, error) {
+	cfg, err := steps.Config(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	items, err := ScanItems(ctx, cfg, table)
+	if err != nil {
+		return ctx, err
+	}
+
+	if len(items) != expected {
+		return ctx, fmt.Errorf("expected %d items in the table '%s', but got %d", expected, table, len(items))
+	}
+
+	return ctx, nil
+}
+
+func (steps *Steps) theTableShouldContainAnItemMatching(ctx context.Context, table string, item *godog.DocString) (context.Context, error) {
+	cfg, err := steps.Config(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	var expected any
+	if err := json.Unmarshal([]byte(item.Content), &expected); err != nil {
+		return ctx, fmt.Errorf("invalid expected item: %w", err)
+	}
+
+	items, err := ScanItems(ctx, cfg, table)
+	if err != nil {
+		return ctx, err
+	}
+
+	var closest []string
+	for i, actual := range items {
+		diffs := subset.Diff(expected, actual)
+		if len(diffs) == 0 {
+			return ctx, nil
+		}
+		if i == 0 || len(diffs) < len(closest) {
+			closest = diffs
+		}
+	}
+
+	if closest == nil {
+		return ctx, fmt.Errorf("no item of the table '%s' matches, the table is empty", table)
+	}
+
+	return ctx, fmt.Errorf("no item of the table '%s' matches (%d items), closest item:%s", table, len(items), subset.Format(closest))
+}
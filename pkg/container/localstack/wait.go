@@ -0,0 +1,210 @@
+package localstack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	HealthPath    string = "/_localstack/health"
+	InitReadyPath string = "/_localstack/init/ready"
+)
+
+// ErrInitScriptFailed is the error returned when an init script of the LocalStack container fails
+var ErrInitScriptFailed = errors.New("init script failed")
+
+// Health is a type that represents the response of the health endpoint
+type Health struct {
+	Services map[string]string `json:"services"`
+}
+
+// Ready returns an error when one of the given services is not "available" or "running", when no service is given
+// all the services that are not disabled are checked
+func (h Health) Ready(services ...Service) error {
+	names := make([]string, 0, len(services))
+	for _, service := range services {
+		names = append(names, string(service))
+	}
+
+	if len(names) == 0 {
+		for name, state := range h.Services {
+			if state != "disabled" {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+	}
+
+	var pending []string
+	for _, name := range names {
+		state, ok := h.Services[name]
+		if !ok {
+			state = "missing"
+		}
+		if state != "available" && state != "running" {
+			pending = append(pending, fmt.Sprintf("%s (%s)", name, state))
+		}
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("services not ready: %s", strings.Join(pending, ", "))
+	}
+
+	return nil
+}
+
+// InitScript is a type that represents the state of an init script
+type InitScript struct {
+	Stage string `json:"stage"`
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+// InitStatus is a type that represents the response of the init ready endpoint
+type InitStatus struct {
+	Completed bool         `json:"completed"`
+	Scripts   []InitScript `json:"scripts"`
+}
+
+// Ready returns true when all the init scripts have completed, and an ErrInitScriptFailed error when one of them failed
+func (s InitStatus) Ready() (bool, error) {
+	var failed []string
+	for _, script := range s.Scripts {
+		if script.State == "ERROR" {
+			failed = append(failed, script.Name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return false, fmt.Errorf("%w: %s", ErrInitScriptFailed, strings.Join(failed, ", "))
+	}
+
+	return s.Completed, nil
+}
+
+// HealthStrategy is a wait strategy that polls the health and init endpoints of the LocalStack container until
+// every requested service is available and all the init scripts have completed
+type HealthStrategy struct {
+	options        *Options
+	startupTimeout time.Duration
+	pollInterval   time.Duration
+}
+
+// ForHealth returns a wait strategy that checks the services set by WithServices
+//
+//	Default startup timeout: 60 seconds
+//	Default poll interval: 500 milliseconds
+func ForHealth(opts ...LocalStackOption) *HealthStrategy {
+	return &HealthStrategy{
+		options:        buildOptions(opts...),
+		startupTimeout: 60 * time.Second,
+		pollInterval:   500 * time.Millisecond,
+	}
+}
+
+// WithStartupTimeout sets the time to wait for the services and the init scripts
+func (s *HealthStrategy) WithStartupTimeout(timeout time.Duration) *HealthStrategy {
+	s.startupTimeout = timeout
+	return s
+}
+
+// WithPollInterval sets the interval between the checks
+func (s *HealthStrategy) WithPollInterval(interval time.Duration) *HealthStrategy {
+	s.pollInterval = interval
+	return s
+}
+
+// Timeout returns the startup timeout of the strategy
+func (s *HealthStrategy) Timeout() *time.Duration {
+	return &s.startupTimeout
+}
+
+// WaitUntilReady polls the endpoints until the container is ready, an init script fails or the startup timeout is reached
+func (s *HealthStrategy) WaitUntilReady(ctx context.Context, target wait.StrategyTarget) error {
+	ctx, cancel := context.WithTimeout(ctx, s.startupTimeout)
+	defer cancel()
+
+	var lastErr error
+	for {
+		lastErr = s.check(ctx, target)
+		if lastErr == nil {
+			return nil
+		}
+		if errors.Is(lastErr, ErrInitScriptFailed) {
+			return lastErr
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("the LocalStack container is not ready: %w", lastErr)
+		case <-time.After(s.pollInterval):
+		}
+	}
+}
+
+func (s *HealthStrategy) check(ctx context.Context, target wait.StrategyTarget) error {
+	host, err := target.Host(ctx)
+	if err != nil {
+		return err
+	}
+
+	port, err := target.MappedPort(ctx, nat.Port(s.options.ExposedPort))
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("http://%s:%s", host, port.Port())
+
+	var health Health
+	if err := getJSON(ctx, endpoint+HealthPath, &health); err != nil {
+		return err
+	}
+
+	if err := health.Ready(s.options.Services...); err != nil {
+		return err
+	}
+
+	var status InitStatus
+	if err := getJSON(ctx, endpoint+InitReadyPath, &status); err != nil {
+		return err
+	}
+
+	completed, err := status.Ready()
+	if err != nil {
+		return err
+	}
+
+	if !completed {
+		return fmt.Errorf("init scripts not completed")
+	}
+
+	return nil
+}
+
+func getJSON(ctx context.Context, url string, val any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(val)
+}
@@ -0,0 +1,95 @@
+package localstack_test
+
+import (
+	"testing"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container/localstack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealth(t *testing.T) {
+	t.Run("Should be ready when the requested services are available or running", func(t *testing.T) {
+		// Arrange
+		health := localstack.Health{
+			Services: map[string]string{
+				"sqs":    "running",
+				"sns":    "available",
+				"lambda": "disabled",
+			},
+		}
+
+		// Act
+		err := health.Ready(localstack.SQS, localstack.SNS)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should report the services that are not ready", func(t *testing.T) {
+		// Arrange
+		health := localstack.Health{
+			Services: map[string]string{
+				"sqs": "initializing",
+			},
+		}
+
+		// Act
+		err := health.Ready(localstack.SQS, localstack.SNS)
+
+		// Assert
+		assert.EqualError(t, err, "services not ready: sqs (initializing), sns (missing)")
+	})
+
+	t.Run("Should check all the enabled services when none is requested", func(t *testing.T) {
+		// Arrange
+		health := localstack.Health{
+			Services: map[string]string{
+				"sqs":    "available",
+				"s3":     "error",
+				"lambda": "disabled",
+			},
+		}
+
+		// Act
+		err := health.Ready()
+
+		// Assert
+		assert.EqualError(t, err, "services not ready: s3 (error)")
+	})
+}
+
+func TestInitStatus(t *testing.T) {
+	t.Run("Should be ready when the scripts have completed", func(t *testing.T) {
+		// Arrange
+		status := localstack.InitStatus{
+			Completed: true,
+			Scripts: []localstack.InitScript{
+				{Stage: "READY", Name: "init-sqs.sh", State: "SUCCESSFUL"},
+			},
+		}
+
+		// Act
+		ready, err := status.Ready()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, ready)
+	})
+
+	t.Run("Should return an error when a script failed", func(t *testing.T) {
+		// Arrange
+		status := localstack.InitStatus{
+			Scripts: []localstack.InitScript{
+				{Stage: "READY", Name: "init-sqs.sh", State: "SUCCESSFUL"},
+				{Stage: "READY", Name: "init-sns.sh", State: "ERROR"},
+			},
+		}
+
+		// Act
+		ready, err := status.Ready()
+
+		// Assert
+		assert.ErrorIs(t, err, localstack.ErrInitScriptFailed)
+		assert.False(t, ready)
+	})
+}
@@ -0,0 +1,58 @@
+package container
+
+import (
+	"context"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// Module is a type that represents a container module, such as a database or a cloud emulator, so the modules
+// can be handled the same way by the stacks and the step libraries
+type Module interface {
+	// Definition returns the options of the container definition
+	Definition() []ContainerOption
+	// Readiness returns the strategy used to wait for the container to be ready
+	Readiness() wait.Strategy
+	// InternalEndpoint returns the endpoint of the container for the containers of the same network
+	InternalEndpoint(ctx context.Context, container testcontainers.Container) (string, error)
+	// ExternalEndpoint returns the endpoint of the container for the host
+	ExternalEndpoint(ctx context.Context, container testcontainers.Container) (string, error)
+	// Reset resets the state of the container without restarting it
+	Reset(ctx context.Context, container testcontainers.Container) error
+	// EnvForDependents returns the environment variables that configure the containers that depend on this one
+	EnvForDependents(ctx context.Context, container testcontainers.Container) (map[string]string, error)
+}
+
+// NewModuleDefinition returns a new container definition for the given module, followed by the given options
+//
+// Example:
+//
+//	definition := container.NewModuleDefinition(
+//		postgres.NewModule(postgres.WithDatabase("orders")),
+//		container.WithNetwork(ntwrkDefinition.Alias, network),
+//	)
+func NewModuleDefinition(module Module, opts ...ContainerOption) *Container {
+	container := NewContainerDefinition(module.Definition()...)
+	container.ContainerRequest.WaitingFor = module.Readiness()
+
+	for _, opt := range opts {
+		opt(container)
+	}
+
+	return container
+}
+
+// ModuleResetHook returns a reset hook that resets the given container of the module
+//
+// Example:
+//
+//	group := container.BuildGroupContainer(
+//		container.WithDockerContainer(pgContainer),
+//		container.WithResetHook(container.ModuleResetHook(module, pgContainer)),
+//	)
+func ModuleResetHook(module Module, target testcontainers.Container) ResetFunc {
+	return func(ctx context.Context) error {
+		return module.Reset(ctx, target)
+	}
+}
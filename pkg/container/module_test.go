@@ -0,0 +1,74 @@
+package container_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+type fakeModule struct {
+	resets int
+}
+
+func (m *fakeModule) Definition() []container.ContainerOption {
+	return []container.ContainerOption{
+		container.WithImage("fake:1"),
+		container.WithExposedPorts("8080"),
+	}
+}
+
+func (m *fakeModule) Readiness() wait.Strategy {
+	return wait.ForListeningPort("8080")
+}
+
+func (m *fakeModule) InternalEndpoint(ctx context.Context, target testcontainers.Container) (string, error) {
+	return "http://fake:8080", nil
+}
+
+func (m *fakeModule) ExternalEndpoint(ctx context.Context, target testcontainers.Container) (string, error) {
+	return "http://localhost:8080", nil
+}
+
+func (m *fakeModule) Reset(ctx context.Context, target testcontainers.Container) error {
+	m.resets++
+	return nil
+}
+
+func (m *fakeModule) EnvForDependents(ctx context.Context, target testcontainers.Container) (map[string]string, error) {
+	return map[string]string{"FAKE_URL": "http://fake:8080"}, nil
+}
+
+func TestNewModuleDefinition(t *testing.T) {
+	t.Run("Should apply the module definition, its readiness and the given options", func(t *testing.T) {
+		// Act
+		definition := container.NewModuleDefinition(&fakeModule{},
+			container.WithExposedPorts("9090"),
+		)
+
+		// Assert
+		assert.Equal(t, "fake:1", definition.ContainerRequest.Image)
+		assert.Equal(t, []string{"9090"}, definition.ContainerRequest.ExposedPorts)
+		assert.Equal(t, wait.ForListeningPort("8080"), definition.ContainerRequest.WaitingFor)
+	})
+}
+
+func TestModuleResetHook(t *testing.T) {
+	t.Run("Should reset the module", func(t *testing.T) {
+		// Arrange
+		module := &fakeModule{}
+		group := container.BuildGroupContainer(
+			container.WithResetHook(container.ModuleResetHook(module, nil)),
+		)
+
+		// Act
+		_, err := container.ResetGroup(context.Background(), group)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, module.resets)
+	})
+}
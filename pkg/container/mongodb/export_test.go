@@ -0,0 +1,48 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Options exposes the options of the cluster to the tests
+func (c *ShardedCluster) Options() *ShardedClusterOptions {
+	return c.options
+}
+
+// MemberArgs exposes the command of the config server and shard members to the tests
+func MemberArgs(node *ClusterNode, role string) []string {
+	return memberArgs(node, role)
+}
+
+// RouterArgs exposes the command of the router to the tests
+func (c *ShardedCluster) RouterArgs() []string {
+	return c.routerArgs()
+}
+
+// ReplicaSetConfig exposes the configuration given to replSetInitiate to the tests
+func ReplicaSetConfig(node *ClusterNode, role string) bson.D {
+	return replicaSetConfig(node, role)
+}
+
+// AddShardCommand exposes the command registering a shard to the tests
+func AddShardCommand(shard *ClusterNode) bson.D {
+	return addShardCommand(shard)
+}
+
+// Commands exposes the commands sharding the collection to the tests
+func (collection ShardedCollection) Commands() (bson.D, bson.D, error) {
+	return collection.commands()
+}
+
+// SetNewClient replaces the function connecting to the database reset by the module, until the returned function
+// is called
+func SetNewClient(connect func(ctx context.Context, connInfo ConnInfo) (*mongo.Client, error)) (restore func()) {
+	previous := newClient
+	newClient = connect
+	return func() {
+		newClient = previous
+	}
+}
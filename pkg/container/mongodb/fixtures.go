@@ -0,0 +1,294 @@
+package mongodb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const indexesSuffix string = ".indexes.json"
+
+// Fixture is a type that represents the documents and indexes of a collection
+type Fixture struct {
+	Database   string
+	Collection string
+	Documents  []bson.D
+	Indexes    []mongo.IndexModel
+}
+
+// FixtureOptions is a type that represents the options used to read the fixtures
+//
+//	Default options:
+//		Database: ""
+//		TemplateData: nil
+type FixtureOptions struct {
+	Database     string
+	TemplateData any
+}
+
+// FixtureOption is a type that represents a fixture option
+type FixtureOption func(*FixtureOptions)
+
+// WithFixtureDatabase is a FixtureOption that sets the database of the files named "collection.json"
+//
+//	Default: ""
+func WithFixtureDatabase(database string) FixtureOption {
+	return func(options *FixtureOptions) {
+		options.Database = database
+	}
+}
+
+// WithTemplateData is a FixtureOption that sets the data used to render the fixture files as Go templates
+//
+//	Default: nil
+//
+// Example:
+//
+//	mongodb.WithTemplateData(map[string]any{
+//		"CustomerId": "66a0c2b1e4b0a1a2b3c4d5e6",
+//	})
+func WithTemplateData(data any) FixtureOption {
+	return func(options *FixtureOptions) {
+		options.TemplateData = data
+	}
+}
+
+// ReadFixtures reads the fixtures of the JSON files at the root of the given file system
+//
+// Each "database.collection.json" file contains an array of documents written in MongoDB Extended JSON,
+// and the optional "database.collection.indexes.json" file contains the indexes of the collection:
+//
+//	[
+//		{"keys": {"email": 1}, "options": {"name": "email_1", "unique": true}}
+//	]
+//
+// The files are rendered as Go templates before being parsed, with the data set by WithTemplateData and
+// the functions "now" (RFC 3339 UTC timestamp) and "objectId" (new ObjectId hex)
+func ReadFixtures(fsys fs.FS, opts ...FixtureOption) ([]Fixture, error) {
+	fixtureOptions := &FixtureOptions{}
+
+	for _, o := range opts {
+		o(fixtureOptions)
+	}
+
+	files, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the fixtures: %w", err)
+	}
+	sort.Strings(files)
+
+	fixtures := make(map[string]*Fixture)
+	var order []string
+
+	for _, file := range files {
+		name := strings.TrimSuffix(file, ".json")
+		isIndexes := strings.HasSuffix(file, indexesSuffix)
+		if isIndexes {
+			name = strings.TrimSuffix(file, indexesSuffix)
+		}
+
+		database, collection, err := splitNamespace(name, fixtureOptions.Database)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fixture '%s': %w", file, err)
+		}
+
+		key := database + "." + collection
+		fixture, ok := fixtures[key]
+		if !ok {
+			fixture = &Fixture{
+				Database:   database,
+				Collection: collection,
+			}
+			fixtures[key] = fixture
+			order = append(order, key)
+		}
+
+		data, err := renderFixture(fsys, file, fixtureOptions.TemplateData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render the fixture '%s': %w", file, err)
+		}
+
+		if isIndexes {
+			fixture.Indexes, err = parseIndexes(data)
+		} else {
+			fixture.Documents, err = parseDocuments(data)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the fixture '%s': %w", file, err)
+		}
+	}
+
+	output := make([]Fixture, len(order))
+	for i, key := range order {
+		output[i] = *fixtures[key]
+	}
+
+	return output, nil
+}
+
+// LoadFixtures reads the fixtures of the given file system, creates the declared indexes and inserts the documents
+//
+// Example:
+//
+//	err := mongodb.LoadFixtures(ctx, client, os.DirFS("./testdata"))
+func LoadFixtures(ctx context.Context, client *mongo.Client, fsys fs.FS, opts ...FixtureOption) error {
+	fixtures, err := ReadFixtures(fsys, opts...)
+	if err != nil {
+		return err
+	}
+
+	for _, fixture := range fixtures {
+		collection := client.Database(fixture.Database).Collection(fixture.Collection)
+
+		if len(fixture.Indexes) > 0 {
+			if _, err := collection.Indexes().CreateMany(ctx, fixture.Indexes); err != nil {
+				return fmt.Errorf("failed to create the indexes of '%s.%s': %w", fixture.Database, fixture.Collection, err)
+			}
+		}
+
+		if len(fixture.Documents) > 0 {
+			documents := make([]any, len(fixture.Documents))
+			for i, document := range fixture.Documents {
+				documents[i] = document
+			}
+
+			if _, err := collection.InsertMany(ctx, documents); err != nil {
+				return fmt.Errorf("failed to insert the documents of '%s.%s': %w", fixture.Database, fixture.Collection, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadFixturesDir is like LoadFixtures, reading the fixtures from the given directory
+//
+// Example:
+//
+//	err := mongodb.LoadFixturesDir(ctx, client, "./testdata")
+func LoadFixturesDir(ctx context.Context, client *mongo.Client, dir string, opts ...FixtureOption) error {
+	return LoadFixtures(ctx, client, os.DirFS(dir), opts...)
+}
+
+func splitNamespace(name string, defaultDatabase string) (string, string, error) {
+	if database, collection, ok := strings.Cut(name, "."); ok {
+		return database, collection, nil
+	}
+
+	if defaultDatabase == "" {
+		return "", "", fmt.Errorf("the file must be named 'database.collection.json' when no database is set")
+	}
+
+	return defaultDatabase, name, nil
+}
+
+func renderFixture(fsys fs.FS, file string, data any) ([]byte, error) {
+	content, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(path.Base(file)).
+		Option("missingkey=error").
+		Funcs(template.FuncMap{
+			"now": func() string {
+				return time.Now().UTC().Format(time.RFC3339)
+			},
+			"objectId": func() string {
+				return primitive.NewObjectID().Hex()
+			},
+		}).
+		Parse(string(content))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func parseDocuments(data []byte) ([]bson.D, error) {
+	var wrapper struct {
+		Documents []bson.D `bson:"documents"`
+	}
+
+	if err := unmarshalArray(data, &wrapper); err != nil {
+		return nil, err
+	}
+
+	return wrapper.Documents, nil
+}
+
+func parseIndexes(data []byte) ([]mongo.IndexModel, error) {
+	var wrapper struct {
+		Indexes []struct {
+			Keys    bson.D `bson:"keys"`
+			Options struct {
+				Name                    *string `bson:"name"`
+				Unique                  *bool   `bson:"unique"`
+				Sparse                  *bool   `bson:"sparse"`
+				ExpireAfterSeconds      *int32  `bson:"expireAfterSeconds"`
+				PartialFilterExpression bson.D  `bson:"partialFilterExpression"`
+			} `bson:"options"`
+		} `bson:"documents"`
+	}
+
+	if err := unmarshalArray(data, &wrapper); err != nil {
+		return nil, err
+	}
+
+	models := make([]mongo.IndexModel, len(wrapper.Indexes))
+	for i, index := range wrapper.Indexes {
+		if len(index.Keys) == 0 {
+			return nil, fmt.Errorf("index %d has no keys", i+1)
+		}
+
+		indexOptions := options.Index()
+		if index.Options.Name != nil {
+			indexOptions.SetName(*index.Options.Name)
+		}
+		if index.Options.Unique != nil {
+			indexOptions.SetUnique(*index.Options.Unique)
+		}
+		if index.Options.Sparse != nil {
+			indexOptions.SetSparse(*index.Options.Sparse)
+		}
+		if index.Options.ExpireAfterSeconds != nil {
+			indexOptions.SetExpireAfterSeconds(*index.Options.ExpireAfterSeconds)
+		}
+		if index.Options.PartialFilterExpression != nil {
+			indexOptions.SetPartialFilterExpression(index.Options.PartialFilterExpression)
+		}
+
+		models[i] = mongo.IndexModel{
+			Keys:    index.Keys,
+			Options: indexOptions,
+		}
+	}
+
+	return models, nil
+}
+
+// unmarshalArray parses a top level Extended JSON array, which must be wrapped in a document to be decoded
+func unmarshalArray(data []byte, val any) error {
+	wrapped := append([]byte(`{"documents": `), data...)
+	wrapped = append(wrapped, '}')
+
+	return bson.UnmarshalExtJSON(wrapped, false, val)
+}
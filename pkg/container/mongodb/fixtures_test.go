@@ -0,0 +1,80 @@
+package mongodb_test
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container/mongodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestReadFixtures(t *testing.T) {
+	t.Run("Should read the documents and indexes of each collection", func(t *testing.T) {
+		// Arrange
+		fsys := fstest.MapFS{
+			"shop.orders.json": {Data: []byte(`[
+				{"_id": {"$oid": "66a0c2b1e4b0a1a2b3c4d5e6"}, "createdAt": {"$date": "2024-01-02T03:04:05Z"}, "total": 10}
+			]`)},
+			"shop.orders.indexes.json": {Data: []byte(`[
+				{"keys": {"customerId": 1, "createdAt": -1}, "options": {"name": "customer_created", "unique": true}}
+			]`)},
+			"README.md": {Data: []byte("ignored")},
+		}
+
+		// Act
+		fixtures, err := mongodb.ReadFixtures(fsys)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, fixtures, 1)
+		assert.Equal(t, "shop", fixtures[0].Database)
+		assert.Equal(t, "orders", fixtures[0].Collection)
+
+		require.Len(t, fixtures[0].Documents, 1)
+		document := fixtures[0].Documents[0].Map()
+		id, _ := primitive.ObjectIDFromHex("66a0c2b1e4b0a1a2b3c4d5e6")
+		assert.Equal(t, id, document["_id"])
+		assert.Equal(t, primitive.NewDateTimeFromTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)), document["createdAt"])
+
+		require.Len(t, fixtures[0].Indexes, 1)
+		assert.Equal(t, bson.D{{Key: "customerId", Value: int32(1)}, {Key: "createdAt", Value: int32(-1)}}, fixtures[0].Indexes[0].Keys)
+		assert.Equal(t, "customer_created", *fixtures[0].Indexes[0].Options.Name)
+		assert.True(t, *fixtures[0].Indexes[0].Options.Unique)
+	})
+
+	t.Run("Should use the default database and render the templates", func(t *testing.T) {
+		// Arrange
+		fsys := fstest.MapFS{
+			"customers.json": {Data: []byte(`[{"name": "{{ .Name }}"}]`)},
+		}
+
+		// Act
+		fixtures, err := mongodb.ReadFixtures(fsys,
+			mongodb.WithFixtureDatabase("shop"),
+			mongodb.WithTemplateData(map[string]any{"Name": "John"}),
+		)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, fixtures, 1)
+		assert.Equal(t, "shop", fixtures[0].Database)
+		assert.Equal(t, "John", fixtures[0].Documents[0].Map()["name"])
+	})
+
+	t.Run("Should return an error when the database is unknown", func(t *testing.T) {
+		// Arrange
+		fsys := fstest.MapFS{
+			"customers.json": {Data: []byte(`[]`)},
+		}
+
+		// Act
+		_, err := mongodb.ReadFixtures(fsys)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
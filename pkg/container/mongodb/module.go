@@ -0,0 +1,110 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// newClient connects to the database reset by the module
+var newClient = func(ctx context.Context, connInfo ConnInfo) (*mongo.Client, error) {
+	return connInfo.Client(ctx)
+}
+
+var _ container.Module = (*Module)(nil)
+
+// Module is a type that represents the MongoDB module
+type Module struct {
+	opts []MongoOption
+	keep []string
+	drop bool
+}
+
+// NewModule returns the MongoDB module with the given options
+//
+// Example:
+//
+//	module := mongodb.NewModule(
+//		mongodb.WithDatabase("shop"),
+//		mongodb.WithNetwork(ntwrkDefinition),
+//	)
+func NewModule(opts ...MongoOption) *Module {
+	return &Module{
+		opts: opts,
+	}
+}
+
+// WithKeepDatabases sets the databases left untouched by the reset of the module
+//
+//	Default: none
+func (m *Module) WithKeepDatabases(keep ...string) *Module {
+	m.keep = keep
+	return m
+}
+
+// WithDropDatabases makes the reset of the module drop the databases, see Reset, instead of clearing their
+// collections, see Clear. The collections, indexes and validators created by the init scripts are then lost
+// after the first reset, the init scripts running only on the first start.
+func (m *Module) WithDropDatabases() *Module {
+	m.drop = true
+	return m
+}
+
+// Definition returns the options of the MongoDB container definition
+func (m *Module) Definition() []container.ContainerOption {
+	return []container.ContainerOption{
+		WithMongoContainer(m.opts...),
+	}
+}
+
+// Readiness returns the strategy used to wait for the MongoDB container to be ready
+func (m *Module) Readiness() wait.Strategy {
+	return ForPing(m.opts...).
+		WithStartupTimeout(30 * time.Second)
+}
+
+// InternalEndpoint returns the connection string of the container for the containers of the same network
+func (m *Module) InternalEndpoint(ctx context.Context, container testcontainers.Container) (string, error) {
+	return BuildInternalConnectionString(ctx, container, m.opts...)
+}
+
+// ExternalEndpoint returns the connection string of the container for the host
+func (m *Module) ExternalEndpoint(ctx context.Context, container testcontainers.Container) (string, error) {
+	return BuildExternalConnectionString(ctx, container, m.opts...)
+}
+
+// Reset deletes the documents of the non-system databases, keeping their collections and indexes, see Clear
+func (m *Module) Reset(ctx context.Context, container testcontainers.Container) error {
+	connInfo, err := BuildExternalConnInfo(ctx, container, m.opts...)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient(ctx, connInfo)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(ctx)
+
+	if m.drop {
+		return Reset(ctx, client, m.keep...)
+	}
+
+	return Clear(ctx, client, m.keep...)
+}
+
+// EnvForDependents returns the connection string as MONGODB_URI
+func (m *Module) EnvForDependents(ctx context.Context, container testcontainers.Container) (map[string]string, error) {
+	uri, err := BuildInternalConnectionString(ctx, container, m.opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"MONGODB_URI": uri,
+	}, nil
+}
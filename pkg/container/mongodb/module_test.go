@@ -0,0 +1,97 @@
+package mongodb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/jfelipearaujo/testcontainers/pkg/container/mongodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// stubContainer is a started container answering only the host and the mapped ports
+type stubContainer struct {
+	testcontainers.Container
+}
+
+func (stubContainer) Host(context.Context) (string, error) {
+	return "localhost", nil
+}
+
+func (stubContainer) MappedPort(_ context.Context, port nat.Port) (nat.Port, error) {
+	return nat.Port("49153/" + port.Proto()), nil
+}
+
+// commands returns the names of the commands sent by the client, without the ones of the disconnection
+func commands(mt *mtest.T) []string {
+	var names []string
+	for event := mt.GetStartedEvent(); event != nil; event = mt.GetStartedEvent() {
+		if event.CommandName != "endSessions" {
+			names = append(names, event.CommandName)
+		}
+	}
+	return names
+}
+
+func TestModuleReset(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	databases := mtest.CreateSuccessResponse(bson.E{Key: "databases", Value: bson.A{
+		bson.D{{Key: "name", Value: "admin"}},
+		bson.D{{Key: "name", Value: "shop"}},
+		bson.D{{Key: "name", Value: "reference"}},
+	}})
+
+	// connect gives the mock client to the module, then replaces it with an idle client, the module disconnecting
+	// the mock client that mtest would disconnect again
+	connect := func(mt *mtest.T) func() {
+		restore := mongodb.SetNewClient(func(context.Context, mongodb.ConnInfo) (*mongo.Client, error) {
+			return mt.Client, nil
+		})
+		return func() {
+			restore()
+			idle, err := mongo.Connect(context.Background())
+			require.NoError(mt, err)
+			mt.Client = idle
+		}
+	}
+
+	mt.Run("Should clear the collections of the databases not kept", func(mt *mtest.T) {
+		// Arrange
+		defer connect(mt)()
+		mt.AddMockResponses(
+			databases,
+			mtest.CreateCursorResponse(0, "shop.$cmd.listCollections", mtest.FirstBatch,
+				bson.D{{Key: "name", Value: "orders"}, {Key: "type", Value: "collection"}},
+			),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 2}),
+		)
+		module := mongodb.NewModule().WithKeepDatabases("reference")
+
+		// Act
+		err := module.Reset(context.Background(), stubContainer{})
+
+		// Assert
+		require.NoError(mt, err)
+		assert.Equal(mt, []string{"listDatabases", "listCollections", "delete"}, commands(mt))
+	})
+
+	mt.Run("Should drop the databases not kept when asked", func(mt *mtest.T) {
+		// Arrange
+		defer connect(mt)()
+		mt.AddMockResponses(databases, mtest.CreateSuccessResponse())
+		module := mongodb.NewModule().WithKeepDatabases("reference").WithDropDatabases()
+
+		// Act
+		err := module.Reset(context.Background(), stubContainer{})
+
+		// Assert
+		require.NoError(mt, err)
+		assert.Equal(mt, []string{"listDatabases", "dropDatabase"}, commands(mt))
+	})
+}
@@ -0,0 +1,128 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+const (
+	KeyFilePath string = "/tmp/mongodb-keyfile"
+
+	// keyFileContent is the shared secret required by mongod when a replica set runs with authentication
+	keyFileContent string = "dGVzdGNvbnRhaW5lcnMtbW9uZ29kYi1yZXBsaWNhLXNldC1rZXlmaWxl"
+)
+
+// WithReplicaSet is a MongoOption that starts the MongoDB container as a single node replica set with the given name,
+// enabling transactions and change streams
+//
+// The node is registered with the network alias when the container is in a network, so internal clients can use
+// the replicaSet parameter, while external clients connect with directConnection. The same options, including
+// WithNetwork, must be given to the preset and to the connection string builders
+//
+//	Default: ""
+//
+// Example:
+//
+//	definition := container.NewContainerDefinition(
+//		container.WithNetwork(ntwrkDefinition.Alias, network),
+//		mongodb.WithMongoContainer(
+//			mongodb.WithNetwork(ntwrkDefinition),
+//			mongodb.WithReplicaSet("rs0"),
+//		),
+//	)
+func WithReplicaSet(name string) MongoOption {
+	return func(options *Options) {
+		options.ReplicaSet = name
+	}
+}
+
+// withReplicaSet starts mongod with --replSet and initiates the replica set once the container is ready
+func withReplicaSet(c *container.Container, options *Options) {
+	script := fmt.Sprintf(
+		`echo "%s" > %s && chmod 400 %s && chown 999:999 %s && exec docker-entrypoint.sh mongod --replSet %s --keyFile %s --bind_ip_all`,
+		keyFileContent,
+		KeyFilePath,
+		KeyFilePath,
+		KeyFilePath,
+		options.ReplicaSet,
+		KeyFilePath)
+
+	c.ContainerRequest.Entrypoint = []string{"bash", "-c"}
+	c.ContainerRequest.Cmd = []string{script}
+	c.ContainerRequest.LifecycleHooks = append(c.ContainerRequest.LifecycleHooks, testcontainers.ContainerLifecycleHooks{
+		PostReadies: []testcontainers.ContainerHook{
+			func(ctx context.Context, target testcontainers.Container) error {
+				return initiateReplicaSet(ctx, target, options)
+			},
+		},
+	})
+}
+
+// initiateReplicaSet runs rs.initiate, unless already initiated, and waits for the node to become PRIMARY
+func initiateReplicaSet(ctx context.Context, target testcontainers.Container, options *Options) error {
+	host := "localhost"
+	if options.NetworkAlias != nil {
+		host = *options.NetworkAlias
+	}
+
+	initiate := fmt.Sprintf(
+		`try { rs.status().ok } catch (e) { rs.initiate({_id: "%s", members: [{_id: 0, host: "%s:%s"}]}).ok }`,
+		options.ReplicaSet,
+		host,
+		ExposedPort)
+
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	var lastErr error
+	for {
+		output, err := execMongosh(ctx, target, options, initiate)
+		if err == nil && strings.HasSuffix(output, "1") {
+			output, err = execMongosh(ctx, target, options, "db.hello().isWritablePrimary")
+			if err == nil && strings.HasSuffix(output, "true") {
+				return nil
+			}
+		}
+		lastErr = err
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("replica set '%s' did not elect a PRIMARY: %v", options.ReplicaSet, lastErr)
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// execMongosh evaluates the script with mongosh inside the container, authenticated as the root user
+func execMongosh(ctx context.Context, target testcontainers.Container, options *Options, script string) (string, error) {
+	cmd := []string{
+		"mongosh", "--quiet",
+		"--username", options.User,
+		"--password", options.Pass,
+		"--authenticationDatabase", "admin",
+		"--eval", script,
+	}
+
+	code, reader, err := target.Exec(ctx, cmd, tcexec.Multiplexed())
+	if err != nil {
+		return "", err
+	}
+
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	if code != 0 {
+		return "", fmt.Errorf("mongosh exited with code %d: %s", code, output)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,89 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// systemDatabases are the databases that are never dropped or cleared
+var systemDatabases = []string{"admin", "config", "local"}
+
+// Reset drops all the non-system databases, except the ones to keep
+//
+// Example:
+//
+//	err := mongodb.Reset(ctx, client, "reference_data")
+func Reset(ctx context.Context, client *mongo.Client, keep ...string) error {
+	databases, err := userDatabases(ctx, client, keep)
+	if err != nil {
+		return err
+	}
+
+	for _, database := range databases {
+		if err := client.Database(database).Drop(ctx); err != nil {
+			return fmt.Errorf("failed to drop the database '%s': %w", database, err)
+		}
+	}
+
+	return nil
+}
+
+// Clear deletes the documents of all the collections of the non-system databases, except the ones to keep,
+// preserving the collections and their indexes
+func Clear(ctx context.Context, client *mongo.Client, keep ...string) error {
+	databases, err := userDatabases(ctx, client, keep)
+	if err != nil {
+		return err
+	}
+
+	for _, database := range databases {
+		collections, err := client.Database(database).ListCollectionNames(ctx, bson.D{{Key: "type", Value: "collection"}})
+		if err != nil {
+			return fmt.Errorf("failed to list the collections of '%s': %w", database, err)
+		}
+
+		for _, collection := range collections {
+			if _, err := client.Database(database).Collection(collection).DeleteMany(ctx, bson.D{}); err != nil {
+				return fmt.Errorf("failed to clear the collection '%s.%s': %w", database, collection, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ResetHook returns a reset hook that calls Reset, to be registered on a shared group of containers
+//
+// Example:
+//
+//	group := container.BuildGroupContainer(
+//		container.WithDockerContainer(mongoContainer),
+//		container.WithResetHook(mongodb.ResetHook(client)),
+//	)
+func ResetHook(client *mongo.Client, keep ...string) container.ResetFunc {
+	return func(ctx context.Context) error {
+		return Reset(ctx, client, keep...)
+	}
+}
+
+func userDatabases(ctx context.Context, client *mongo.Client, keep []string) ([]string, error) {
+	names, err := client.ListDatabaseNames(ctx, bson.D{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the databases: %w", err)
+	}
+
+	var databases []string
+	for _, name := range names {
+		if slices.Contains(systemDatabases, name) || slices.Contains(keep, name) {
+			continue
+		}
+		databases = append(databases, name)
+	}
+
+	return databases, nil
+}
@@ -0,0 +1,453 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/jfelipearaujo/testcontainers/pkg/network"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/sync/errgroup"
+)
+
+// ShardedCollection is a type that represents a collection that will be sharded by the given key
+type ShardedCollection struct {
+	Namespace string
+	Key       bson.D
+}
+
+// ShardedClusterOptions is a type that represents the options of a sharded cluster
+//
+//	Default options:
+//		Image: "mongo:7"
+//		Shards: 2
+//		AliasPrefix: "mongo"
+//		StartupTimeout: "60 seconds"
+//		Collections: nil
+type ShardedClusterOptions struct {
+	Image          string
+	Shards         int
+	AliasPrefix    string
+	StartupTimeout time.Duration
+	Collections    []ShardedCollection
+}
+
+// ShardedClusterOption is a type that represents a sharded cluster option
+type ShardedClusterOption func(*ShardedClusterOptions)
+
+// WithClusterImage is a ShardedClusterOption that sets the image of the nodes
+//
+//	Default: "mongo:7"
+func WithClusterImage(image string) ShardedClusterOption {
+	return func(options *ShardedClusterOptions) {
+		options.Image = image
+	}
+}
+
+// WithShards is a ShardedClusterOption that sets the number of shards, each one being a single node replica set
+//
+//	Default: 2
+func WithShards(shards int) ShardedClusterOption {
+	return func(options *ShardedClusterOptions) {
+		options.Shards = shards
+	}
+}
+
+// WithClusterAliasPrefix is a ShardedClusterOption that sets the prefix of the network aliases of the nodes
+//
+//	Default: "mongo" (mongo-config, mongo-shard-1, ..., mongo-router)
+func WithClusterAliasPrefix(prefix string) ShardedClusterOption {
+	return func(options *ShardedClusterOptions) {
+		options.AliasPrefix = prefix
+	}
+}
+
+// WithClusterStartupTimeout is a ShardedClusterOption that sets the startup timeout of each node
+//
+//	Default: 60 seconds
+func WithClusterStartupTimeout(timeout time.Duration) ShardedClusterOption {
+	return func(options *ShardedClusterOptions) {
+		options.StartupTimeout = timeout
+	}
+}
+
+// WithShardedCollection is a ShardedClusterOption that shards the given "database.collection" by the given key
+//
+//	Default: nil
+//
+// Example:
+//
+//	mongodb.WithShardedCollection("shop.orders", bson.D{{Key: "customerId", Value: "hashed"}})
+func WithShardedCollection(namespace string, key bson.D) ShardedClusterOption {
+	return func(options *ShardedClusterOptions) {
+		options.Collections = append(options.Collections, ShardedCollection{
+			Namespace: namespace,
+			Key:       key,
+		})
+	}
+}
+
+// ClusterNode is a type that represents a node of a sharded cluster
+type ClusterNode struct {
+	Alias      string
+	ReplicaSet string
+	Container  testcontainers.Container
+}
+
+// ShardedCluster is a type that represents a config server replica set, N shard replica sets and a mongos router
+// sharing a network, started without authentication
+type ShardedCluster struct {
+	ConfigServer *ClusterNode
+	Shards       []*ClusterNode
+	Router       *ClusterNode
+
+	network *network.Network
+	options *ShardedClusterOptions
+}
+
+// NewShardedCluster creates a new sharded cluster definition that will be started on the given network
+//
+// Example:
+//
+//	cluster := mongodb.NewShardedCluster(ntwrkDefinition,
+//		mongodb.WithShards(2),
+//		mongodb.WithShardedCollection("shop.orders", bson.D{{Key: "customerId", Value: "hashed"}}),
+//	)
+//	if err := cluster.Build(ctx); err != nil {
+//		return ctx, err
+//	}
+//
+//	connInfo, err := cluster.ExternalConnInfo(ctx)
+func NewShardedCluster(ntw *network.Network, opts ...ShardedClusterOption) *ShardedCluster {
+	options := &ShardedClusterOptions{
+		Image:          Image,
+		Shards:         2,
+		AliasPrefix:    "mongo",
+		StartupTimeout: 60 * time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return &ShardedCluster{
+		network: ntw,
+		options: options,
+	}
+}
+
+// Build starts the config server and the shards in parallel, then the router, registers the shards
+// and shards the declared collections
+//
+// When the cluster fails to start, the nodes already started are terminated.
+func (c *ShardedCluster) Build(ctx context.Context) error {
+	if err := c.build(ctx); err != nil {
+		return errors.Join(err, c.terminate(context.WithoutCancel(ctx)))
+	}
+
+	return nil
+}
+
+func (c *ShardedCluster) build(ctx context.Context) error {
+	if c.options.Shards < 1 {
+		return fmt.Errorf("a sharded cluster needs at least 1 shard, got %d", c.options.Shards)
+	}
+
+	for _, collection := range c.options.Collections {
+		if _, _, err := collection.commands(); err != nil {
+			return err
+		}
+	}
+
+	dockerNetwork, err := c.network.Build(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.ConfigServer = &ClusterNode{
+		Alias:      c.options.AliasPrefix + "-config",
+		ReplicaSet: c.options.AliasPrefix + "-config",
+	}
+
+	c.Shards = make([]*ClusterNode, c.options.Shards)
+	for i := range c.Shards {
+		c.Shards[i] = &ClusterNode{
+			Alias:      fmt.Sprintf("%s-shard-%d", c.options.AliasPrefix, i+1),
+			ReplicaSet: fmt.Sprintf("%s-shard-%d", c.options.AliasPrefix, i+1),
+		}
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+
+	group.Go(func() error {
+		return c.startReplicaSet(groupCtx, dockerNetwork, c.ConfigServer, "--configsvr")
+	})
+
+	for _, shard := range c.Shards {
+		group.Go(func() error {
+			return c.startReplicaSet(groupCtx, dockerNetwork, shard, "--shardsvr")
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return err
+	}
+
+	c.Router = &ClusterNode{
+		Alias: c.options.AliasPrefix + "-router",
+	}
+
+	c.Router.Container, err = c.startNode(ctx, dockerNetwork, c.Router.Alias, c.routerArgs())
+	if err != nil {
+		return fmt.Errorf("failed to start the router: %w", err)
+	}
+
+	return c.configureRouter(ctx)
+}
+
+// terminate terminates the nodes already started and forgets them
+func (c *ShardedCluster) terminate(ctx context.Context) error {
+	var errs []error
+	for _, node := range c.Containers() {
+		if err := node.Terminate(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	c.ConfigServer = nil
+	c.Shards = nil
+	c.Router = nil
+
+	return errors.Join(errs...)
+}
+
+// Containers returns the containers of all the nodes, to be destroyed with the group
+func (c *ShardedCluster) Containers() []testcontainers.Container {
+	var containers []testcontainers.Container
+
+	if c.Router != nil && c.Router.Container != nil {
+		containers = append(containers, c.Router.Container)
+	}
+
+	for _, shard := range c.Shards {
+		if shard.Container != nil {
+			containers = append(containers, shard.Container)
+		}
+	}
+
+	if c.ConfigServer != nil && c.ConfigServer.Container != nil {
+		containers = append(containers, c.ConfigServer.Container)
+	}
+
+	return containers
+}
+
+// InternalConnInfo returns the connection information of the router for containers in the same network
+func (c *ShardedCluster) InternalConnInfo() ConnInfo {
+	return ConnInfo{
+		Host: c.Router.Alias,
+		Port: nat.Port(ExposedPort).Port(),
+	}
+}
+
+// ExternalConnInfo returns the connection information of the router for clients running on the host
+func (c *ShardedCluster) ExternalConnInfo(ctx context.Context) (ConnInfo, error) {
+	return nodeConnInfo(ctx, c.Router.Container)
+}
+
+func (c *ShardedCluster) startReplicaSet(ctx context.Context, dockerNetwork *testcontainers.DockerNetwork, node *ClusterNode, role string) error {
+	var err error
+
+	node.Container, err = c.startNode(ctx, dockerNetwork, node.Alias, memberArgs(node, role))
+	if err != nil {
+		return fmt.Errorf("failed to start %s: %w", node.Alias, err)
+	}
+
+	return c.initiate(ctx, node, replicaSetConfig(node, role))
+}
+
+// memberArgs returns the command of a config server or shard member
+func memberArgs(node *ClusterNode, role string) []string {
+	return []string{
+		"mongod",
+		role,
+		"--replSet", node.ReplicaSet,
+		"--port", ExposedPort,
+		"--bind_ip_all",
+	}
+}
+
+// routerArgs returns the command of the mongos router pointing to the config server
+func (c *ShardedCluster) routerArgs() []string {
+	return []string{
+		"mongos",
+		"--configdb", fmt.Sprintf("%s/%s:%s", c.ConfigServer.ReplicaSet, c.ConfigServer.Alias, ExposedPort),
+		"--port", ExposedPort,
+		"--bind_ip_all",
+	}
+}
+
+// replicaSetConfig returns the single member configuration given to replSetInitiate
+func replicaSetConfig(node *ClusterNode, role string) bson.D {
+	config := bson.D{
+		{Key: "_id", Value: node.ReplicaSet},
+		{Key: "members", Value: bson.A{
+			bson.D{
+				{Key: "_id", Value: 0},
+				{Key: "host", Value: fmt.Sprintf("%s:%s", node.Alias, ExposedPort)},
+			},
+		}},
+	}
+	if role == "--configsvr" {
+		config = append(config, bson.E{Key: "configsvr", Value: true})
+	}
+
+	return config
+}
+
+func (c *ShardedCluster) startNode(ctx context.Context, dockerNetwork *testcontainers.DockerNetwork, alias string, cmd []string) (testcontainers.Container, error) {
+	return container.NewContainerDefinition(
+		container.WithNetwork(alias, dockerNetwork),
+		func(definition *container.Container) {
+			definition.ContainerRequest.Image = c.options.Image
+			definition.ContainerRequest.ExposedPorts = []string{
+				ExposedPort,
+			}
+			definition.ContainerRequest.Cmd = cmd
+			definition.ContainerRequest.WaitingFor = wait.
+				ForLog("Waiting for connections").
+				WithStartupTimeout(c.options.StartupTimeout)
+		},
+	).BuildContainer(ctx)
+}
+
+// initiate runs replSetInitiate on the node and waits for it to become PRIMARY
+func (c *ShardedCluster) initiate(ctx context.Context, node *ClusterNode, config bson.D) error {
+	ctx, cancel := context.WithTimeout(ctx, c.options.StartupTimeout)
+	defer cancel()
+
+	client, err := nodeClient(ctx, node.Container)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(ctx)
+
+	err = client.Database("admin").RunCommand(ctx, bson.D{{Key: "replSetInitiate", Value: config}}).Err()
+	if err != nil && !isAlreadyInitialized(err) {
+		return fmt.Errorf("failed to initiate %s: %w", node.ReplicaSet, err)
+	}
+
+	for {
+		var hello struct {
+			IsWritablePrimary bool `bson:"isWritablePrimary"`
+		}
+
+		err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello)
+		if err == nil && hello.IsWritablePrimary {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("replica set '%s' did not elect a PRIMARY: %w", node.ReplicaSet, ctx.Err())
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// configureRouter registers the shards and shards the declared collections
+func (c *ShardedCluster) configureRouter(ctx context.Context) error {
+	client, err := nodeClient(ctx, c.Router.Container)
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(ctx)
+
+	admin := client.Database("admin")
+
+	for _, shard := range c.Shards {
+		if err := admin.RunCommand(ctx, addShardCommand(shard)).Err(); err != nil {
+			return fmt.Errorf("failed to add the shard %s: %w", shard.ReplicaSet, err)
+		}
+	}
+
+	for _, collection := range c.options.Collections {
+		enableSharding, shardCollection, err := collection.commands()
+		if err != nil {
+			return err
+		}
+
+		if err := admin.RunCommand(ctx, enableSharding).Err(); err != nil {
+			return fmt.Errorf("failed to enable sharding for '%s': %w", collection.Namespace, err)
+		}
+
+		if err := admin.RunCommand(ctx, shardCollection).Err(); err != nil {
+			return fmt.Errorf("failed to shard the collection '%s': %w", collection.Namespace, err)
+		}
+	}
+
+	return nil
+}
+
+// addShardCommand returns the command registering the replica set of the shard in the router
+func addShardCommand(shard *ClusterNode) bson.D {
+	seed := fmt.Sprintf("%s/%s:%s", shard.ReplicaSet, shard.Alias, ExposedPort)
+	return bson.D{{Key: "addShard", Value: seed}}
+}
+
+// commands returns the commands enabling the sharding of the database and sharding the collection
+func (collection ShardedCollection) commands() (bson.D, bson.D, error) {
+	database, _, err := splitNamespace(collection.Namespace, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid sharded collection '%s': %w", collection.Namespace, err)
+	}
+
+	enableSharding := bson.D{{Key: "enableSharding", Value: database}}
+	shardCollection := bson.D{
+		{Key: "shardCollection", Value: collection.Namespace},
+		{Key: "key", Value: collection.Key},
+	}
+
+	return enableSharding, shardCollection, nil
+}
+
+func nodeConnInfo(ctx context.Context, node testcontainers.Container) (ConnInfo, error) {
+	host, err := node.Host(ctx)
+	if err != nil {
+		return ConnInfo{}, fmt.Errorf("failed to get the host: %w", err)
+	}
+
+	port, err := node.MappedPort(ctx, nat.Port(ExposedPort))
+	if err != nil {
+		return ConnInfo{}, fmt.Errorf("failed to get the mapped port: %w", err)
+	}
+
+	return ConnInfo{
+		Host:             host,
+		Port:             port.Port(),
+		DirectConnection: true,
+	}, nil
+}
+
+func nodeClient(ctx context.Context, node testcontainers.Container) (*mongo.Client, error) {
+	connInfo, err := nodeConnInfo(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+
+	return connInfo.Client(ctx)
+}
+
+func isAlreadyInitialized(err error) bool {
+	var commandErr mongo.CommandError
+	if errors.As(err, &commandErr) {
+		return commandErr.Code == 23
+	}
+	return false
+}
@@ -0,0 +1,207 @@
+package mongodb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container/mongodb"
+	"github.com/jfelipearaujo/testcontainers/pkg/internal/containerfake"
+	"github.com/jfelipearaujo/testcontainers/pkg/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestNewShardedCluster(t *testing.T) {
+	t.Run("Should use the default options", func(t *testing.T) {
+		// Act
+		cluster := mongodb.NewShardedCluster(network.NewNetwork())
+
+		// Assert
+		assert.Equal(t, &mongodb.ShardedClusterOptions{
+			Image:          mongodb.Image,
+			Shards:         2,
+			AliasPrefix:    "mongo",
+			StartupTimeout: 60 * time.Second,
+		}, cluster.Options())
+	})
+
+	t.Run("Should honor the options", func(t *testing.T) {
+		// Act
+		cluster := mongodb.NewShardedCluster(network.NewNetwork(),
+			mongodb.WithClusterImage("mongo:6"),
+			mongodb.WithShards(3),
+			mongodb.WithClusterAliasPrefix("shop"),
+			mongodb.WithClusterStartupTimeout(time.Minute*2),
+			mongodb.WithShardedCollection("shop.orders", bson.D{{Key: "customerId", Value: "hashed"}}),
+			mongodb.WithShardedCollection("shop.items", bson.D{{Key: "sku", Value: 1}}),
+		)
+
+		// Assert
+		assert.Equal(t, &mongodb.ShardedClusterOptions{
+			Image:          "mongo:6",
+			Shards:         3,
+			AliasPrefix:    "shop",
+			StartupTimeout: 2 * time.Minute,
+			Collections: []mongodb.ShardedCollection{
+				{Namespace: "shop.orders", Key: bson.D{{Key: "customerId", Value: "hashed"}}},
+				{Namespace: "shop.items", Key: bson.D{{Key: "sku", Value: 1}}},
+			},
+		}, cluster.Options())
+	})
+}
+
+func TestShardedClusterCommands(t *testing.T) {
+	t.Run("Should start the config server member", func(t *testing.T) {
+		// Arrange
+		node := &mongodb.ClusterNode{Alias: "mongo-config", ReplicaSet: "mongo-config"}
+
+		// Act
+		args := mongodb.MemberArgs(node, "--configsvr")
+		config := mongodb.ReplicaSetConfig(node, "--configsvr")
+
+		// Assert
+		assert.Equal(t, []string{
+			"mongod", "--configsvr",
+			"--replSet", "mongo-config",
+			"--port", mongodb.ExposedPort,
+			"--bind_ip_all",
+		}, args)
+		assert.Equal(t, bson.D{
+			{Key: "_id", Value: "mongo-config"},
+			{Key: "members", Value: bson.A{
+				bson.D{{Key: "_id", Value: 0}, {Key: "host", Value: "mongo-config:" + mongodb.ExposedPort}},
+			}},
+			{Key: "configsvr", Value: true},
+		}, config)
+	})
+
+	t.Run("Should start the shard member", func(t *testing.T) {
+		// Arrange
+		node := &mongodb.ClusterNode{Alias: "mongo-shard-1", ReplicaSet: "mongo-shard-1"}
+
+		// Act
+		args := mongodb.MemberArgs(node, "--shardsvr")
+		config := mongodb.ReplicaSetConfig(node, "--shardsvr")
+
+		// Assert
+		assert.Equal(t, []string{
+			"mongod", "--shardsvr",
+			"--replSet", "mongo-shard-1",
+			"--port", mongodb.ExposedPort,
+			"--bind_ip_all",
+		}, args)
+		assert.Equal(t, bson.D{
+			{Key: "_id", Value: "mongo-shard-1"},
+			{Key: "members", Value: bson.A{
+				bson.D{{Key: "_id", Value: 0}, {Key: "host", Value: "mongo-shard-1:" + mongodb.ExposedPort}},
+			}},
+		}, config)
+	})
+
+	t.Run("Should point the router to the config server", func(t *testing.T) {
+		// Arrange
+		cluster := mongodb.NewShardedCluster(network.NewNetwork())
+		cluster.ConfigServer = &mongodb.ClusterNode{Alias: "mongo-config", ReplicaSet: "mongo-config"}
+
+		// Act
+		args := cluster.RouterArgs()
+
+		// Assert
+		assert.Equal(t, []string{
+			"mongos",
+			"--configdb", "mongo-config/mongo-config:" + mongodb.ExposedPort,
+			"--port", mongodb.ExposedPort,
+			"--bind_ip_all",
+		}, args)
+	})
+
+	t.Run("Should register the shard by its replica set", func(t *testing.T) {
+		// Arrange
+		shard := &mongodb.ClusterNode{Alias: "mongo-shard-2", ReplicaSet: "mongo-shard-2"}
+
+		// Act
+		command := mongodb.AddShardCommand(shard)
+
+		// Assert
+		assert.Equal(t, bson.D{{Key: "addShard", Value: "mongo-shard-2/mongo-shard-2:" + mongodb.ExposedPort}}, command)
+	})
+
+	t.Run("Should enable the sharding of the database and shard the collection", func(t *testing.T) {
+		// Arrange
+		collection := mongodb.ShardedCollection{
+			Namespace: "shop.orders",
+			Key:       bson.D{{Key: "customerId", Value: "hashed"}},
+		}
+
+		// Act
+		enableSharding, shardCollection, err := collection.Commands()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, bson.D{{Key: "enableSharding", Value: "shop"}}, enableSharding)
+		assert.Equal(t, bson.D{
+			{Key: "shardCollection", Value: "shop.orders"},
+			{Key: "key", Value: bson.D{{Key: "customerId", Value: "hashed"}}},
+		}, shardCollection)
+	})
+
+	t.Run("Should refuse a collection without database", func(t *testing.T) {
+		// Arrange
+		collection := mongodb.ShardedCollection{Namespace: "orders"}
+
+		// Act
+		_, _, err := collection.Commands()
+
+		// Assert
+		assert.ErrorContains(t, err, "invalid sharded collection 'orders'")
+	})
+}
+
+func TestShardedClusterBuild(t *testing.T) {
+	t.Run("Should refuse a cluster without shards", func(t *testing.T) {
+		// Arrange
+		cluster := mongodb.NewShardedCluster(network.NewNetwork(), mongodb.WithShards(0))
+
+		// Act
+		err := cluster.Build(context.Background())
+
+		// Assert
+		assert.ErrorContains(t, err, "a sharded cluster needs at least 1 shard, got 0")
+		assert.Empty(t, cluster.Containers())
+	})
+
+	t.Run("Should refuse an invalid sharded collection before starting the nodes", func(t *testing.T) {
+		// Arrange
+		cluster := mongodb.NewShardedCluster(network.NewNetwork(),
+			mongodb.WithShardedCollection("orders", bson.D{{Key: "_id", Value: 1}}),
+		)
+
+		// Act
+		err := cluster.Build(context.Background())
+
+		// Assert
+		assert.ErrorContains(t, err, "invalid sharded collection 'orders'")
+		assert.Empty(t, cluster.Containers())
+	})
+
+	t.Run("Should terminate the nodes already started on failure", func(t *testing.T) {
+		// Arrange
+		config, shard, router := &containerfake.Container{}, &containerfake.Container{}, &containerfake.Container{}
+		cluster := mongodb.NewShardedCluster(network.NewNetwork(), mongodb.WithShards(0))
+		cluster.ConfigServer = &mongodb.ClusterNode{Alias: "mongo-config", Container: config}
+		cluster.Shards = []*mongodb.ClusterNode{{Alias: "mongo-shard-1", Container: shard}}
+		cluster.Router = &mongodb.ClusterNode{Alias: "mongo-router", Container: router}
+
+		// Act
+		err := cluster.Build(context.Background())
+
+		// Assert
+		assert.Error(t, err)
+		assert.True(t, config.Terminated)
+		assert.True(t, shard.Terminated)
+		assert.True(t, router.Terminated)
+		assert.Empty(t, cluster.Containers())
+	})
+}
@@ -0,0 +1,207 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cucumber/godog"
+	"github.com/jfelipearaujo/testcontainers/pkg/internal/subset"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// maxReportedDocuments is the number of closest documents reported when no document matches
+const maxReportedDocuments int = 3
+
+// Steps is a type that represents the collection steps of a MongoDB database
+type Steps struct {
+	Client   func(ctx context.Context) (*mongo.Client, error)
+	Database string
+}
+
+// StepsOption is a type that represents a Steps option
+type StepsOption func(*Steps)
+
+// WithDefaultDatabase is a StepsOption that sets the database of the collections named without a database
+//
+// Default: ""
+func WithDefaultDatabase(database string) StepsOption {
+	return func(steps *Steps) {
+		steps.Database = database
+	}
+}
+
+// RegisterSteps registers the collection steps on the given scenario context
+//
+// The client function must return the client of the current scenario. The collections are named
+// "database.collection" or "collection" when WithDefaultDatabase is set. The registered steps are:
+//
+//	Given the fixtures in "./testdata" are loaded
+//	Then the collection "orders" should contain 2 documents
+//	Then the collection "orders" should contain a document matching:
+//	Then the collection "orders" should contain a document matching, ignoring "_id, createdAt":
+//	Then the collection "orders" should not contain a document matching:
+//
+// The documents are written in relaxed MongoDB Extended JSON and are matched as a subset of the stored documents
+func RegisterSteps(ctx *godog.ScenarioContext, client func(ctx context.Context) (*mongo.Client, error), opts ...StepsOption) *Steps {
+	steps := &Steps{
+		Client: client,
+	}
+
+	for _, opt := range opts {
+		opt(steps)
+	}
+
+	ctx.Step(`^the fixtures in "([^"]*)" are loaded$`, steps.theFixturesAreLoaded)
+	ctx.Step(`^the collection "([^"]*)" should contain (\d+) documents?$`, steps.theCollectionShouldContainDocuments)
+	ctx.Step(`^the collection "([^"]*)" should contain a document matching:$`, steps.theCollectionShouldContainADocumentMatching)
+	ctx.Step(`^the collection "([^"]*)" should contain a document matching, ignoring "([^"]*)":$`, steps.theCollectionShouldContainADocumentMatchingIgnoring)
+	ctx.Step(`^the collection "([^"]*)" should not contain a document matching:$`, steps.theCollectionShouldNotContainADocumentMatching)
+
+	return steps
+}
+
+func (steps *Steps) theFixturesAreLoaded(ctx context.Context, dir string) (context.Context, error) {
+	client, err := steps.Client(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	return ctx, LoadFixturesDir(ctx, client, dir, WithFixtureDatabase(steps.Database))
+}
+
+func (steps *Steps) theCollectionShouldContainDocuments(ctx context.Context, name string, expected int64) (context.Context, error) {
+	collection, err := steps.collection(ctx, name)
+	if err != nil {
+		return ctx, err
+	}
+
+	count, err := collection.CountDocuments(ctx, bson.D{})
+	if err != nil {
+		return ctx, fmt.Errorf("failed to count the documents of '%s': %w", name, err)
+	}
+
+	if count != expected {
+		return ctx, fmt.Errorf("expected %d documents in the collection '%s', but got %d", expected, name, count)
+	}
+
+	return ctx, nil
+}
+
+func (steps *Steps) theCollectionShouldContainADocumentMatching(ctx context.Context, name string, document *godog.DocString) (context.Context, error) {
+	return ctx, steps.match(ctx, name, document.Content, nil)
+}
+
+func (steps *Steps) theCollectionShouldContainADocumentMatchingIgnoring(ctx context.Context, name string, fields string, document *godog.DocString) (context.Context, error) {
+	ignore := strings.Split(fields, ",")
+	for i := range ignore {
+		ignore[i] = strings.TrimSpace(ignore[i])
+	}
+
+	return ctx, steps.match(ctx, name, document.Content, ignore)
+}
+
+func (steps *Steps) theCollectionShouldNotContainADocumentMatching(ctx context.Context, name string, document *godog.DocString) (context.Context, error) {
+	err := steps.match(ctx, name, document.Content, nil)
+	if err == nil {
+		return ctx, fmt.Errorf("expected no document of the collection '%s' to match, but one did", name)
+	}
+	var mismatch *MismatchError
+	if errors.As(err, &mismatch) {
+		return ctx, nil
+	}
+	return ctx, err
+}
+
+// MismatchError is the error returned when no document matches the expected document
+type MismatchError struct {
+	Collection string
+	Total      int
+	Closest    [][]string
+}
+
+func (e *MismatchError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "no document of the collection '%s' matches (%d documents)", e.Collection, e.Total)
+	for i, diffs := range e.Closest {
+		fmt.Fprintf(&sb, "\n  closest document %d:%s", i+1, subset.Format(diffs))
+	}
+	return sb.String()
+}
+
+// match returns nil when a document of the collection contains the expected document
+//
+// The expected document is decoded before reading the collection, so a malformed document fails even when
+// the collection is empty.
+func (steps *Steps) match(ctx context.Context, name string, expected string, ignore []string) error {
+	var expectedDocument map[string]any
+	if err := json.Unmarshal([]byte(expected), &expectedDocument); err != nil {
+		return fmt.Errorf("failed to decode the expected document: %w", err)
+	}
+	if expectedDocument == nil {
+		return fmt.Errorf("the expected document must be a JSON object")
+	}
+
+	collection, err := steps.collection(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := collection.Find(ctx, bson.D{})
+	if err != nil {
+		return fmt.Errorf("failed to read the collection '%s': %w", name, err)
+	}
+	defer cursor.Close(ctx)
+
+	var candidates [][]string
+	for cursor.Next(ctx) {
+		actual, err := bson.MarshalExtJSON(cursor.Current, false, false)
+		if err != nil {
+			return fmt.Errorf("failed to encode a document of '%s': %w", name, err)
+		}
+
+		var actualDocument map[string]any
+		if err := json.Unmarshal(actual, &actualDocument); err != nil {
+			return fmt.Errorf("failed to decode a document of '%s': %w", name, err)
+		}
+
+		diffs := subset.Diff(expectedDocument, actualDocument, ignore...)
+		if len(diffs) == 0 {
+			return nil
+		}
+		candidates = append(candidates, diffs)
+	}
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("failed to read the collection '%s': %w", name, err)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return len(candidates[i]) < len(candidates[j])
+	})
+
+	mismatch := &MismatchError{
+		Collection: name,
+		Total:      len(candidates),
+		Closest:    candidates[:min(len(candidates), maxReportedDocuments)],
+	}
+
+	return mismatch
+}
+
+func (steps *Steps) collection(ctx context.Context, name string) (*mongo.Collection, error) {
+	client, err := steps.Client(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	database, collection, err := splitNamespace(name, steps.Database)
+	if err != nil {
+		return nil, fmt.Errorf("invalid collection '%s': %w", name, err)
+	}
+
+	return client.Database(database).Collection(collection), nil
+}
@@ -0,0 +1,161 @@
+package mongodb_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/jfelipearaujo/testcontainers/pkg/container/mongodb"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// runSteps runs a scenario made of the given steps with the client, returning the status and the output
+func runSteps(client *mongo.Client, steps string) (int, string) {
+	output := &strings.Builder{}
+
+	suite := godog.TestSuite{
+		ScenarioInitializer: func(ctx *godog.ScenarioContext) {
+			mongodb.RegisterSteps(ctx, func(ctx context.Context) (*mongo.Client, error) {
+				return client, nil
+			}, mongodb.WithDefaultDatabase("shop"))
+		},
+		Options: &godog.Options{
+			Format: "progress",
+			Output: output,
+			Strict: true,
+			FeatureContents: []godog.Feature{{
+				Name:     "orders.feature",
+				Contents: []byte("Feature: orders\n  Scenario: orders\n" + steps),
+			}},
+		},
+	}
+
+	return suite.Run(), output.String()
+}
+
+func TestRegisterSteps(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	orders := []bson.D{
+		{{Key: "_id", Value: 1}, {Key: "status", Value: "paid"}, {Key: "total", Value: 10}},
+		{{Key: "_id", Value: 2}, {Key: "status", Value: "created"}, {Key: "total", Value: 20}},
+	}
+
+	mt.Run("Should count the documents", func(mt *mtest.T) {
+		// Arrange
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "shop.orders", mtest.FirstBatch, bson.D{{Key: "n", Value: 2}}))
+
+		// Act
+		status, output := runSteps(mt.Client, `
+    Then the collection "orders" should contain 2 documents
+`)
+
+		// Assert
+		assert.Zero(mt, status, output)
+	})
+
+	mt.Run("Should find a document matching", func(mt *mtest.T) {
+		// Arrange
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "shop.orders", mtest.FirstBatch, orders...))
+
+		// Act
+		status, output := runSteps(mt.Client, `
+    Then the collection "orders" should contain a document matching, ignoring "_id":
+      """
+      {"status": "created", "total": 20}
+      """
+`)
+
+		// Assert
+		assert.Zero(mt, status, output)
+	})
+
+	mt.Run("Should report the closest documents", func(mt *mtest.T) {
+		// Arrange
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "shop.orders", mtest.FirstBatch, orders...))
+
+		// Act
+		status, output := runSteps(mt.Client, `
+    Then the collection "orders" should contain a document matching:
+      """
+      {"status": "paid", "total": 20}
+      """
+`)
+
+		// Assert
+		assert.NotZero(mt, status)
+		assert.Contains(mt, output, "no document of the collection 'orders' matches (2 documents)")
+		assert.Contains(mt, output, "closest document 1:")
+	})
+
+	mt.Run("Should fail when a document matches the unexpected document", func(mt *mtest.T) {
+		// Arrange
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "shop.orders", mtest.FirstBatch, orders...))
+
+		// Act
+		status, output := runSteps(mt.Client, `
+    Then the collection "orders" should not contain a document matching:
+      """
+      {"status": "paid"}
+      """
+`)
+
+		// Assert
+		assert.NotZero(mt, status)
+		assert.Contains(mt, output, "expected no document of the collection 'orders' to match, but one did")
+	})
+
+	mt.Run("Should pass when no document matches the unexpected document", func(mt *mtest.T) {
+		// Arrange
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "shop.orders", mtest.FirstBatch, orders...))
+
+		// Act
+		status, output := runSteps(mt.Client, `
+    Then the collection "orders" should not contain a document matching:
+      """
+      {"status": "cancelled"}
+      """
+`)
+
+		// Assert
+		assert.Zero(mt, status, output)
+	})
+
+	mt.Run("Should refuse a malformed unexpected document on an empty collection", func(mt *mtest.T) {
+		// Arrange
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "shop.orders", mtest.FirstBatch))
+
+		// Act
+		status, output := runSteps(mt.Client, `
+    Then the collection "orders" should not contain a document matching:
+      """
+      {"status": "paid"
+      """
+`)
+
+		// Assert
+		assert.NotZero(mt, status)
+		assert.Contains(mt, output, "failed to decode the expected document")
+	})
+
+	mt.Run("Should refuse an unexpected document that is not an object", func(mt *mtest.T) {
+		// Arrange
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "shop.orders", mtest.FirstBatch))
+
+		// Act
+		status, output := runSteps(mt.Client, `
+    Then the collection "orders" should not contain a document matching:
+      """
+      null
+      """
+`)
+
+		// Assert
+		assert.NotZero(mt, status)
+		assert.Contains(mt, output, "the expected document must be a JSON object")
+	})
+}
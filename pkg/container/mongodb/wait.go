@@ -0,0 +1,31 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+)
+
+// PingStrategy is a wait strategy that waits until the MongoDB container answers the ping command
+type PingStrategy = container.PingStrategy
+
+// ForPing returns a wait strategy that connects with the given options and pings the container
+//
+//	Default startup timeout: 60 seconds
+//	Default poll interval: 500 milliseconds
+func ForPing(opts ...MongoOption) *PingStrategy {
+	options := buildOptions(opts...)
+
+	return container.ForPing(options.ExposedPort, func(ctx context.Context, host string, port string) error {
+		connInfo := newConnInfo(options, host, port)
+		// a replica set member is only selectable before rs.initiate with a direct connection
+		connInfo.DirectConnection = true
+
+		client, err := connInfo.Client(ctx)
+		if err != nil {
+			return err
+		}
+
+		return client.Disconnect(ctx)
+	})
+}
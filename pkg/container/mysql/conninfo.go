@@ -0,0 +1,126 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/go-sql-driver/mysql"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// ConnInfo is a type that represents the connection information of a MySQL container
+type ConnInfo struct {
+	Host         string
+	Port         string
+	Database     string
+	User         string
+	Password     string
+	CharacterSet string
+}
+
+// BuildInternalConnInfo returns the connection information for the given container when the container is in a network
+//
+//	Example: ConnInfo{Host: "network_alias", Port: "3306", ...}
+func BuildInternalConnInfo(ctx context.Context, container testcontainers.Container, opts ...MySQLOption) (ConnInfo, error) {
+	options := buildOptions(opts...)
+
+	if options.NetworkAlias == nil {
+		return ConnInfo{}, fmt.Errorf("the container is not in a network")
+	}
+
+	return newConnInfo(options, *options.NetworkAlias, nat.Port(options.ExposedPort).Port()), nil
+}
+
+// BuildExternalConnInfo returns the connection information for the given container when the container is NOT in a network
+//
+//	Example: ConnInfo{Host: "localhost", Port: "49153", ...}
+func BuildExternalConnInfo(ctx context.Context, container testcontainers.Container, opts ...MySQLOption) (ConnInfo, error) {
+	options := buildOptions(opts...)
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		return ConnInfo{}, fmt.Errorf("failed to get the host: %w", err)
+	}
+
+	mappedPort, err := container.MappedPort(ctx, nat.Port(options.ExposedPort))
+	if err != nil {
+		return ConnInfo{}, fmt.Errorf("failed to get the mapped port: %w", err)
+	}
+
+	return newConnInfo(options, host, mappedPort.Port()), nil
+}
+
+func newConnInfo(options *Options, host string, port string) ConnInfo {
+	password := options.Pass
+	if options.User == RootUser {
+		password = options.RootPass
+	}
+
+	return ConnInfo{
+		Host:         host,
+		Port:         port,
+		Database:     options.Database,
+		User:         options.User,
+		Password:     password,
+		CharacterSet: options.CharacterSet,
+	}
+}
+
+// Config returns the go-sql-driver configuration of the connection, the DATE and DATETIME values being parsed
+// into time.Time values in UTC
+func (c ConnInfo) Config() *mysql.Config {
+	config := mysql.NewConfig()
+	config.User = c.User
+	config.Passwd = c.Password
+	config.Net = "tcp"
+	config.Addr = net.JoinHostPort(c.Host, c.Port)
+	config.DBName = c.Database
+	config.ParseTime = true
+	if c.CharacterSet != "" {
+		config.Params = map[string]string{
+			"charset": c.CharacterSet,
+		}
+	}
+
+	return config
+}
+
+// DSN returns the connection string in the go-sql-driver format
+//
+//	Example: "mysql:mysql@tcp(localhost:3306)/mysql_db?parseTime=true&charset=utf8mb4"
+func (c ConnInfo) DSN() string {
+	return c.Config().FormatDSN()
+}
+
+// String returns the connection string in the go-sql-driver format
+func (c ConnInfo) String() string {
+	return c.DSN()
+}
+
+// Open opens a connection pool to the database and pings it
+//
+// Example:
+//
+//	db, err := connInfo.Open(ctx)
+//	if err != nil {
+//		return ctx, err
+//	}
+//	defer db.Close()
+func (c ConnInfo) Open(ctx context.Context) (*sql.DB, error) {
+	connector, err := mysql.NewConnector(c.Config())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the connection: %w", err)
+	}
+
+	db := sql.OpenDB(connector)
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping the database: %w", err)
+	}
+
+	return db, nil
+}
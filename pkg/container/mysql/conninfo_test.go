@@ -0,0 +1,44 @@
+package mysql_test
+
+import (
+	"testing"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnInfo(t *testing.T) {
+	t.Run("Should render the go-sql-driver format", func(t *testing.T) {
+		// Arrange
+		connInfo := mysql.ConnInfo{
+			Host:         "localhost",
+			Port:         "3306",
+			Database:     "mysql_db",
+			User:         "mysql",
+			Password:     "mysql",
+			CharacterSet: "utf8mb4",
+		}
+
+		// Act
+		res := connInfo.DSN()
+
+		// Assert
+		assert.Equal(t, "mysql:mysql@tcp(localhost:3306)/mysql_db?parseTime=true&charset=utf8mb4", res)
+	})
+
+	t.Run("Should parse the DATE and DATETIME values", func(t *testing.T) {
+		// Arrange
+		connInfo := mysql.ConnInfo{
+			Host: "localhost",
+			Port: "3306",
+		}
+
+		// Act
+		res := connInfo.Config()
+
+		// Assert
+		assert.True(t, res.ParseTime)
+		assert.Equal(t, "localhost:3306", res.Addr)
+		assert.Empty(t, res.Params)
+	})
+}
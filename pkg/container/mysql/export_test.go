@@ -0,0 +1,15 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+)
+
+// SetOpenDB replaces the function opening the database reset by the module, until the returned function is called
+func SetOpenDB(open func(ctx context.Context, connInfo ConnInfo) (*sql.DB, error)) (restore func()) {
+	previous := openDB
+	openDB = open
+	return func() {
+		openDB = previous
+	}
+}
@@ -0,0 +1,34 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/internal/sqltable"
+)
+
+// ReadFixtures reads the table fixtures from the given YAML, JSON or CSV files
+//
+// YAML and JSON files map each table name to a list of rows, the tables are returned in the order they are declared:
+//
+//	users:
+//	  - id: 1
+//	    name: John
+//	    email: NULL
+//
+// CSV files contain the rows of a single table, named after the file, with the column names in the header:
+//
+//	id,name,email
+//	1,John,NULL
+func ReadFixtures(files ...string) ([]TableFixture, error) {
+	return sqltable.ReadFixtures(files...)
+}
+
+// LoadFixtures reads the table fixtures from the given files and inserts them into the database
+//
+// Example:
+//
+//	err := mysql.LoadFixtures(ctx, db, "./testdata/users.yaml", "./testdata/orders.csv")
+func LoadFixtures(ctx context.Context, db *sql.DB, files ...string) error {
+	return sqltable.LoadFixtures(ctx, db, dialect{}, files...)
+}
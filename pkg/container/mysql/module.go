@@ -0,0 +1,110 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+var _ container.Module = (*Module)(nil)
+
+// openDB opens the database reset by the module, replaced by the tests
+var openDB = func(ctx context.Context, connInfo ConnInfo) (*sql.DB, error) {
+	return connInfo.Open(ctx)
+}
+
+// Module is a type that represents the MySQL module
+type Module struct {
+	opts         []MySQLOption
+	truncateOpts []TruncateOption
+}
+
+// NewModule returns the MySQL module with the given options
+//
+// Example:
+//
+//	module := mysql.NewModule(
+//		mysql.WithDatabase("orders"),
+//		mysql.WithNetwork(ntwrkDefinition),
+//	)
+//
+//	definition := container.NewModuleDefinition(module,
+//		container.WithNetwork(ntwrkDefinition.Alias, network),
+//	)
+func NewModule(opts ...MySQLOption) *Module {
+	return &Module{
+		opts: opts,
+	}
+}
+
+// WithTruncateOptions sets the options given to TruncateAll by the reset of the module, for instance to keep
+// the migrations tables
+//
+// Example:
+//
+//	module := mysql.NewModule(mysql.WithDatabase("orders")).
+//		WithTruncateOptions(mysql.WithExcludedTables("schema_migrations"))
+func (m *Module) WithTruncateOptions(opts ...TruncateOption) *Module {
+	m.truncateOpts = opts
+	return m
+}
+
+// Definition returns the options of the MySQL container definition
+func (m *Module) Definition() []container.ContainerOption {
+	return []container.ContainerOption{
+		WithMySQLContainer(m.opts...),
+	}
+}
+
+// Readiness returns the strategy used to wait for the MySQL container to be ready
+func (m *Module) Readiness() wait.Strategy {
+	return ForPing(m.opts...).
+		WithStartupTimeout(60 * time.Second)
+}
+
+// InternalEndpoint returns the connection string of the container for the containers of the same network
+func (m *Module) InternalEndpoint(ctx context.Context, container testcontainers.Container) (string, error) {
+	return BuildInternalConnectionString(ctx, container, m.opts...)
+}
+
+// ExternalEndpoint returns the connection string of the container for the host
+func (m *Module) ExternalEndpoint(ctx context.Context, container testcontainers.Container) (string, error) {
+	return BuildExternalConnectionString(ctx, container, m.opts...)
+}
+
+// Reset truncates the tables of the database with the options set by WithTruncateOptions, see TruncateAll
+func (m *Module) Reset(ctx context.Context, container testcontainers.Container) error {
+	connInfo, err := BuildExternalConnInfo(ctx, container, m.opts...)
+	if err != nil {
+		return err
+	}
+
+	db, err := openDB(ctx, connInfo)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return TruncateAll(ctx, db, m.truncateOpts...)
+}
+
+// EnvForDependents returns the DSN as MYSQL_DSN and the connection information as the MYSQL_* environment variables
+func (m *Module) EnvForDependents(ctx context.Context, container testcontainers.Container) (map[string]string, error) {
+	connInfo, err := BuildInternalConnInfo(ctx, container, m.opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"MYSQL_DSN":      connInfo.DSN(),
+		"MYSQL_HOST":     connInfo.Host,
+		"MYSQL_PORT":     connInfo.Port,
+		"MYSQL_DATABASE": connInfo.Database,
+		"MYSQL_USER":     connInfo.User,
+		"MYSQL_PASSWORD": connInfo.Password,
+	}, nil
+}
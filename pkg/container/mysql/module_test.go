@@ -0,0 +1,107 @@
+package mysql_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/jfelipearaujo/testcontainers/pkg/container/mysql"
+	"github.com/jfelipearaujo/testcontainers/pkg/internal/sqlfake"
+	"github.com/jfelipearaujo/testcontainers/pkg/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// stubContainer is a started container answering only the host and the mapped ports
+type stubContainer struct {
+	testcontainers.Container
+}
+
+func (stubContainer) Host(context.Context) (string, error) {
+	return "localhost", nil
+}
+
+func (stubContainer) MappedPort(_ context.Context, port nat.Port) (nat.Port, error) {
+	return nat.Port("49153/" + port.Proto()), nil
+}
+
+func TestModule(t *testing.T) {
+	t.Run("Should honor the options in the definition", func(t *testing.T) {
+		// Arrange
+		module := mysql.NewModule(
+			mysql.WithDatabase("orders"),
+			mysql.WithUser("app"),
+			mysql.WithPass("secret"),
+		)
+
+		// Act
+		definition := container.NewModuleDefinition(module)
+
+		// Assert
+		assert.Equal(t, "orders", definition.ContainerRequest.Env["MYSQL_DATABASE"])
+		assert.Equal(t, "app", definition.ContainerRequest.Env["MYSQL_USER"])
+		assert.Equal(t, "secret", definition.ContainerRequest.Env["MYSQL_PASSWORD"])
+		assert.NotNil(t, definition.ContainerRequest.WaitingFor)
+	})
+
+	t.Run("Should return the environment of the dependents", func(t *testing.T) {
+		// Arrange
+		module := mysql.NewModule(
+			mysql.WithDatabase("orders"),
+			mysql.WithNetwork(network.NewNetwork(network.WithAlias("db"))),
+		)
+
+		// Act
+		env, err := module.EnvForDependents(context.Background(), nil)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "mysql:mysql@tcp(db:3306)/orders?parseTime=true&charset=utf8mb4", env["MYSQL_DSN"])
+		assert.Equal(t, "db", env["MYSQL_HOST"])
+		assert.Equal(t, "3306", env["MYSQL_PORT"])
+		assert.Equal(t, "orders", env["MYSQL_DATABASE"])
+	})
+
+	t.Run("Should keep the excluded tables on reset", func(t *testing.T) {
+		// Arrange
+		module := mysql.NewModule(mysql.WithDatabase("orders")).
+			WithTruncateOptions(mysql.WithExcludedTables("schema_migrations"))
+
+		db := sqlfake.Open(func(query sqlfake.Query) (sqlfake.Rows, error) {
+			if strings.Contains(query.SQL, "information_schema.tables") {
+				return sqlfake.Rows{
+					Columns: []string{"table_schema", "table_name"},
+					Values:  [][]any{{"orders", "schema_migrations"}, {"orders", "users"}},
+				}, nil
+			}
+			return sqlfake.Rows{}, nil
+		})
+		defer db.Close()
+
+		var connInfo mysql.ConnInfo
+		restore := mysql.SetOpenDB(func(_ context.Context, c mysql.ConnInfo) (*sql.DB, error) {
+			connInfo = c
+			return db.DB, nil
+		})
+		defer restore()
+
+		// Act
+		err := module.Reset(context.Background(), stubContainer{})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "49153", connInfo.Port)
+
+		var truncated []string
+		for _, query := range db.Queries() {
+			if strings.HasPrefix(query.SQL, "TRUNCATE") {
+				truncated = append(truncated, query.SQL)
+			}
+		}
+		assert.Equal(t, []string{"TRUNCATE TABLE `orders`.`users`"}, truncated)
+	})
+}
@@ -0,0 +1,254 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/jfelipearaujo/testcontainers/pkg/network"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	Image        string = "mysql:8.4"
+	MariaDBImage string = "mariadb:11.4"
+	BasePath     string = "/docker-entrypoint-initdb.d"
+	ExposedPort  string = "3306"
+	Database     string = "mysql_db"
+	User         string = "mysql"
+	Pass         string = "mysql"
+	RootUser     string = "root"
+	RootPass     string = "root"
+	CharacterSet string = "utf8mb4"
+)
+
+// Options is a type that represents the options for a MySQL container
+//
+//	Default options:
+//		Image: "mysql:8.4"
+//		ExposedPort: "3306"
+//		Database: "mysql_db"
+//		User: "mysql"
+//		Pass: "mysql"
+//		RootPass: "root"
+//		CharacterSet: "utf8mb4"
+//		InitScripts: nil
+//
+//	Default network alias: nil
+type Options struct {
+	Image        string
+	ExposedPort  string
+	Database     string
+	User         string
+	Pass         string
+	RootPass     string
+	CharacterSet string
+	InitScripts  []string
+	NetworkAlias *string
+}
+
+// MySQLOption is a type that represents a MySQL option
+type MySQLOption func(*Options)
+
+// WithImage is a MySQLOption that sets the image of the MySQL container, MariaDB images being supported as well
+//
+//	Default: "mysql:8.4"
+//
+// Example:
+//
+//	mysql.WithImage(mysql.MariaDBImage)
+func WithImage(image string) MySQLOption {
+	return func(options *Options) {
+		options.Image = image
+	}
+}
+
+// WithExposedPort is a MySQLOption that sets the exposed port of the MySQL container
+//
+//	Default: "3306"
+func WithExposedPort(exposedPort string) MySQLOption {
+	return func(options *Options) {
+		options.ExposedPort = exposedPort
+	}
+}
+
+// WithDatabase is a MySQLOption that sets the database of the MySQL container
+//
+//	Default: "mysql_db"
+func WithDatabase(database string) MySQLOption {
+	return func(options *Options) {
+		options.Database = database
+	}
+}
+
+// WithUser is a MySQLOption that sets the user of the MySQL container, the "root" user connecting with the root password
+//
+//	Default: "mysql"
+func WithUser(user string) MySQLOption {
+	return func(options *Options) {
+		options.User = user
+	}
+}
+
+// WithPass is a MySQLOption that sets the password of the user of the MySQL container
+//
+//	Default: "mysql"
+func WithPass(pass string) MySQLOption {
+	return func(options *Options) {
+		options.Pass = pass
+	}
+}
+
+// WithRootPass is a MySQLOption that sets the password of the root user of the MySQL container
+//
+//	Default: "root"
+func WithRootPass(rootPass string) MySQLOption {
+	return func(options *Options) {
+		options.RootPass = rootPass
+	}
+}
+
+// WithCharacterSet is a MySQLOption that sets the character set of the server and of the connection
+//
+//	Default: "utf8mb4"
+func WithCharacterSet(characterSet string) MySQLOption {
+	return func(options *Options) {
+		options.CharacterSet = characterSet
+	}
+}
+
+// WithInitScripts is a MySQLOption that sets the .sql, .sql.gz and .sh scripts that will be copied to the container
+// and executed on the first startup, in alphabetical order, against the database set by WithDatabase
+//
+//	Default: nil
+func WithInitScripts(files ...string) MySQLOption {
+	return func(options *Options) {
+		options.InitScripts = append(options.InitScripts, files...)
+	}
+}
+
+// WithNetwork is a MySQLOption that sets the network alias of the MySQL container
+//
+//	Default: nil
+func WithNetwork(network *network.Network) MySQLOption {
+	return func(options *Options) {
+		options.NetworkAlias = &network.Alias
+	}
+}
+
+// Return a MySQL DSN, in the go-sql-driver format, for the given container with default options when the container is in a network
+//
+//	Example: "mysql:mysql@tcp(network_alias:3306)/mysql_db?parseTime=true&charset=utf8mb4"
+func BuildInternalConnectionString(ctx context.Context, container testcontainers.Container, opts ...MySQLOption) (string, error) {
+	connInfo, err := BuildInternalConnInfo(ctx, container, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	return connInfo.DSN(), nil
+}
+
+// Return a MySQL DSN, in the go-sql-driver format, for the given container with default options when the container is NOT in a network
+//
+//	Example: "mysql:mysql@tcp(localhost:49153)/mysql_db?parseTime=true&charset=utf8mb4"
+func BuildExternalConnectionString(ctx context.Context, container testcontainers.Container, opts ...MySQLOption) (string, error) {
+	connInfo, err := BuildExternalConnInfo(ctx, container, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	return connInfo.DSN(), nil
+}
+
+func buildOptions(opts ...MySQLOption) *Options {
+	options := &Options{
+		Image:        Image,
+		ExposedPort:  ExposedPort,
+		Database:     Database,
+		User:         User,
+		Pass:         Pass,
+		RootPass:     RootPass,
+		CharacterSet: CharacterSet,
+	}
+
+	for _, o := range opts {
+		o(options)
+	}
+
+	return options
+}
+
+// Return a new container definition for a MySQL container with default options
+//
+//	DockerImage: "mysql:8.4"
+//	Exposed ports: "3306"
+//	Environment variables:
+//		MYSQL_DATABASE: "mysql_db"
+//		MYSQL_USER: "mysql"
+//		MYSQL_PASSWORD: "mysql"
+//		MYSQL_ROOT_PASSWORD: "root"
+//	Command: --character-set-server=utf8mb4
+//
+//	BasePath: "/docker-entrypoint-initdb.d"
+//	WaitingForPing: SQL ping
+//	StartupTimeout: "60 seconds"
+//
+// The same MySQLOptions must be given to the connection string builders, so the credentials match the container.
+//
+// Example:
+//
+//	definition := container.NewContainerDefinition(
+//		mysql.WithMySQLContainer(
+//			mysql.WithImage(mysql.MariaDBImage),
+//			mysql.WithDatabase("orders"),
+//			mysql.WithInitScripts("./testdata/schema.sql"),
+//		),
+//	)
+func WithMySQLContainer(opts ...MySQLOption) container.ContainerOption {
+	options := buildOptions(opts...)
+
+	files := make([]testcontainers.ContainerFile, len(options.InitScripts))
+	for i, file := range options.InitScripts {
+		var mode int64
+		switch name := strings.ToLower(file); {
+		case strings.HasSuffix(name, ".sql"), strings.HasSuffix(name, ".sql.gz"):
+			mode = 0644
+		case strings.HasSuffix(name, ".sh"):
+			mode = 0755
+		default:
+			panic(fmt.Errorf("init script '%s' must be a .sql, .sql.gz or .sh file", file))
+		}
+
+		files[i] = testcontainers.ContainerFile{
+			HostFilePath:      file,
+			ContainerFilePath: path.Join(BasePath, filepath.Base(file)),
+			FileMode:          mode,
+		}
+	}
+
+	return func(container *container.Container) {
+		container.ContainerRequest.Image = options.Image
+		container.ContainerRequest.ExposedPorts = []string{
+			options.ExposedPort,
+		}
+		container.ContainerRequest.Env = map[string]string{
+			"MYSQL_DATABASE":      options.Database,
+			"MYSQL_ROOT_PASSWORD": options.RootPass,
+		}
+		// the root user already exists, the image refuses to create it again
+		if options.User != RootUser {
+			container.ContainerRequest.Env["MYSQL_USER"] = options.User
+			container.ContainerRequest.Env["MYSQL_PASSWORD"] = options.Pass
+		}
+		container.ContainerRequest.Cmd = []string{
+			"--character-set-server=" + options.CharacterSet,
+		}
+		container.ContainerRequest.Files = append(container.ContainerRequest.Files, files...)
+		container.ContainerRequest.WaitingFor = ForPing(opts...).
+			WithStartupTimeout(60 * time.Second)
+	}
+}
@@ -0,0 +1,128 @@
+package mysql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/jfelipearaujo/testcontainers/pkg/container/mysql"
+	"github.com/jfelipearaujo/testcontainers/pkg/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMySQLContainer(t *testing.T) {
+	t.Run("Should use the default options", func(t *testing.T) {
+		// Act
+		definition := container.NewContainerDefinition(
+			mysql.WithMySQLContainer(),
+		)
+
+		// Assert
+		assert.Equal(t, mysql.Image, definition.ContainerRequest.Image)
+		assert.Equal(t, []string{mysql.ExposedPort}, definition.ContainerRequest.ExposedPorts)
+		assert.Equal(t, map[string]string{
+			"MYSQL_DATABASE":      mysql.Database,
+			"MYSQL_USER":          mysql.User,
+			"MYSQL_PASSWORD":      mysql.Pass,
+			"MYSQL_ROOT_PASSWORD": mysql.RootPass,
+		}, definition.ContainerRequest.Env)
+		assert.Equal(t, []string{"--character-set-server=utf8mb4"}, definition.ContainerRequest.Cmd)
+		assert.NotNil(t, definition.ContainerRequest.WaitingFor)
+	})
+
+	t.Run("Should honor the options", func(t *testing.T) {
+		// Act
+		definition := container.NewContainerDefinition(
+			mysql.WithMySQLContainer(
+				mysql.WithImage(mysql.MariaDBImage),
+				mysql.WithDatabase("orders"),
+				mysql.WithUser("app"),
+				mysql.WithPass("secret"),
+				mysql.WithRootPass("toor"),
+				mysql.WithCharacterSet("latin1"),
+				mysql.WithInitScripts("./testdata/schema.sql", "./testdata/data.sql.gz", "./testdata/seed.sh"),
+			),
+		)
+
+		// Assert
+		assert.Equal(t, mysql.MariaDBImage, definition.ContainerRequest.Image)
+		assert.Equal(t, map[string]string{
+			"MYSQL_DATABASE":      "orders",
+			"MYSQL_USER":          "app",
+			"MYSQL_PASSWORD":      "secret",
+			"MYSQL_ROOT_PASSWORD": "toor",
+		}, definition.ContainerRequest.Env)
+		assert.Equal(t, []string{"--character-set-server=latin1"}, definition.ContainerRequest.Cmd)
+		assert.Len(t, definition.ContainerRequest.Files, 3)
+		assert.Equal(t, mysql.BasePath+"/schema.sql", definition.ContainerRequest.Files[0].ContainerFilePath)
+		assert.Equal(t, int64(0644), definition.ContainerRequest.Files[1].FileMode)
+		assert.Equal(t, int64(0755), definition.ContainerRequest.Files[2].FileMode)
+	})
+
+	t.Run("Should not create the root user", func(t *testing.T) {
+		// Act
+		definition := container.NewContainerDefinition(
+			mysql.WithMySQLContainer(
+				mysql.WithUser(mysql.RootUser),
+			),
+		)
+
+		// Assert
+		assert.Equal(t, map[string]string{
+			"MYSQL_DATABASE":      mysql.Database,
+			"MYSQL_ROOT_PASSWORD": mysql.RootPass,
+		}, definition.ContainerRequest.Env)
+	})
+
+	t.Run("Should panic for unsupported init scripts", func(t *testing.T) {
+		// Act & Assert
+		assert.Panics(t, func() {
+			mysql.WithMySQLContainer(mysql.WithInitScripts("./testdata/init.js"))
+		})
+	})
+}
+
+func TestBuildInternalConnectionString(t *testing.T) {
+	t.Run("Should return the DSN of the network alias", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		ntwrkDefinition := network.NewNetwork(network.WithAlias("db"))
+
+		// Act
+		res, err := mysql.BuildInternalConnectionString(ctx, nil, mysql.WithNetwork(ntwrkDefinition))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "mysql:mysql@tcp(db:3306)/mysql_db?parseTime=true&charset=utf8mb4", res)
+	})
+
+	t.Run("Should connect as root with the root password", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		ntwrkDefinition := network.NewNetwork(network.WithAlias("db"))
+
+		// Act
+		res, err := mysql.BuildInternalConnInfo(ctx, nil,
+			mysql.WithNetwork(ntwrkDefinition),
+			mysql.WithUser(mysql.RootUser),
+			mysql.WithRootPass("toor"),
+		)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "root", res.User)
+		assert.Equal(t, "toor", res.Password)
+	})
+
+	t.Run("Should return an error when the container is not in a network", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		// Act
+		_, err := mysql.BuildInternalConnectionString(ctx, nil)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,62 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/cucumber/godog"
+	"github.com/jfelipearaujo/testcontainers/pkg/internal/sqltable"
+)
+
+// StepsName is the default name qualifying the tables and the fixtures in the steps
+const StepsName string = "MySQL"
+
+// Steps is a type that represents the table steps of a MySQL database
+type Steps = sqltable.Steps
+
+// StepsOption is a type that represents a Steps option
+type StepsOption = sqltable.StepsOption
+
+// WithVariables is a StepsOption that sets the function used to resolve the {{name}} placeholders of the table cells
+//
+// Default: nil
+//
+// Example:
+//
+//	mysql.WithVariables(func(ctx context.Context) map[string]any {
+//		currentState := testState.Retrieve(ctx)
+//		return map[string]any{
+//			"user_id": currentState.userId,
+//		}
+//	})
+func WithVariables(variables func(ctx context.Context) map[string]any) StepsOption {
+	return sqltable.WithVariables(variables)
+}
+
+// WithName is a StepsOption that sets the name qualifying the tables and the fixtures in the steps, an empty
+// name leaving the steps unqualified
+//
+// Default: "MySQL"
+//
+// Example:
+//
+//	mysql.WithName("legacy") // Given the legacy table "customers" contains:
+func WithName(name string) StepsOption {
+	return sqltable.WithName(name)
+}
+
+// RegisterSteps registers the table steps on the given scenario context
+//
+// The db function must return the database of the current scenario. The steps are qualified with "MySQL",
+// so they can be registered with the PostgreSQL ones on the same scenario. The registered steps are:
+//
+//	Given the MySQL table "users" contains:
+//	Then the MySQL table "users" should contain:
+//	Then the MySQL table "users" should contain exactly:
+//	Given the MySQL fixtures "./testdata/users.yaml" are loaded
+//
+// The first row of a table contains the column names, the cell value NULL represents a SQL NULL
+// and {{name}} placeholders are replaced by the values returned by WithVariables
+func RegisterSteps(ctx *godog.ScenarioContext, db func(ctx context.Context) (*sql.DB, error), opts ...StepsOption) *Steps {
+	return sqltable.RegisterSteps(ctx, dialect{}, db, StepsName, opts...)
+}
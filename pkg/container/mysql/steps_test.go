@@ -0,0 +1,82 @@
+package mysql_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/cucumber/godog"
+	"github.com/jfelipearaujo/testcontainers/pkg/container/mysql"
+	"github.com/jfelipearaujo/testcontainers/pkg/container/postgres"
+	"github.com/jfelipearaujo/testcontainers/pkg/internal/sqlfake"
+	"github.com/stretchr/testify/assert"
+)
+
+// usersTable answers the column queries of both dialects for a users table
+func usersTable(query sqlfake.Query) (sqlfake.Rows, error) {
+	if strings.Contains(query.SQL, "information_schema.columns") {
+		return sqlfake.Rows{
+			Columns: []string{"column_name", "data_type"},
+			Values:  [][]any{{"id", "integer"}, {"name", "text"}},
+		}, nil
+	}
+	return sqlfake.Rows{}, nil
+}
+
+func inserts(db *sqlfake.DB) []string {
+	var statements []string
+	for _, query := range db.Queries() {
+		if strings.HasPrefix(query.SQL, "INSERT") {
+			statements = append(statements, query.SQL)
+		}
+	}
+	return statements
+}
+
+func TestRegisterSteps(t *testing.T) {
+	t.Run("Should register the steps with the PostgreSQL ones on the same scenario", func(t *testing.T) {
+		// Arrange
+		pgDB := sqlfake.Open(usersTable)
+		defer pgDB.Close()
+		mysqlDB := sqlfake.Open(usersTable)
+		defer mysqlDB.Close()
+
+		suite := godog.TestSuite{
+			ScenarioInitializer: func(ctx *godog.ScenarioContext) {
+				postgres.RegisterSteps(ctx, func(ctx context.Context) (*sql.DB, error) {
+					return pgDB.DB, nil
+				})
+				mysql.RegisterSteps(ctx, func(ctx context.Context) (*sql.DB, error) {
+					return mysqlDB.DB, nil
+				})
+			},
+			Options: &godog.Options{
+				Format: "progress",
+				Output: &strings.Builder{},
+				Strict: true,
+				FeatureContents: []godog.Feature{{
+					Name: "users.feature",
+					Contents: []byte(`
+Feature: users
+  Scenario: both databases
+    Given the table "users" contains:
+      | id | name |
+      | 1  | John |
+    And the MySQL table "users" contains:
+      | id | name |
+      | 2  | Jane |
+`),
+				}},
+			},
+		}
+
+		// Act
+		status := suite.Run()
+
+		// Assert
+		assert.Zero(t, status)
+		assert.Equal(t, []string{`INSERT INTO "public"."users" ("id", "name") VALUES ($1, $2)`}, inserts(pgDB))
+		assert.Equal(t, []string{"INSERT INTO `users` (`id`, `name`) VALUES (?, ?)"}, inserts(mysqlDB))
+	})
+}
@@ -0,0 +1,113 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/internal/sqltable"
+)
+
+// NullValue is the cell value that represents a SQL NULL in tables and fixtures
+const NullValue string = sqltable.NullValue
+
+// Row is a type that represents a row of a table, indexed by the column name
+type Row = sqltable.Row
+
+// TableFixture is a type that represents the rows that will be inserted into a table
+type TableFixture = sqltable.Fixture
+
+// InsertRows inserts the rows into the given table, as "table" or "database.table", converting the values
+// to the column types
+//
+// String values are converted to the type of the column, nil values are inserted as NULL
+func InsertRows(ctx context.Context, db *sql.DB, fixture TableFixture) error {
+	return sqltable.InsertRows(ctx, db, dialect{}, fixture)
+}
+
+// CompareRows compares the expected rows with the rows stored in the given table
+//
+// Only the columns of the fixture are compared. When exact is true the table must contain
+// only the expected rows, otherwise the expected rows must be a subset of the table rows.
+// The returned error contains a readable diff of the rows
+func CompareRows(ctx context.Context, db *sql.DB, expected TableFixture, exact bool) error {
+	return sqltable.CompareRows(ctx, db, dialect{}, expected, exact)
+}
+
+// dialect is the MySQL flavor of the table helpers
+type dialect struct{}
+
+func (dialect) Placeholder(int) string {
+	return "?"
+}
+
+func (dialect) QuoteIdentifier(name string) string {
+	return quoteIdentifier(name)
+}
+
+func (dialect) QuoteTable(table string) string {
+	database, name := splitTable(table)
+	if database == "" {
+		return quoteIdentifier(name)
+	}
+	return quoteIdentifier(database) + "." + quoteIdentifier(name)
+}
+
+func (dialect) ColumnTypes(ctx context.Context, db *sql.DB, table string) (map[string]sqltable.Kind, error) {
+	database, name := splitTable(table)
+
+	return sqltable.QueryColumnTypes(ctx, db, table, kind,
+		"SELECT column_name, data_type FROM information_schema.columns WHERE table_schema = COALESCE(NULLIF(?, ''), DATABASE()) AND table_name = ?",
+		database,
+		name)
+}
+
+func (dialect) ConvertValue(kind sqltable.Kind, raw any) (any, error) {
+	// BOOLEAN columns are TINYINT(1) columns
+	if value, ok := raw.(string); ok && kind == sqltable.Integer {
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			if b, err := strconv.ParseBool(value); err == nil {
+				if b {
+					return int64(1), nil
+				}
+				return int64(0), nil
+			}
+		}
+	}
+
+	return sqltable.ConvertValue(kind, raw)
+}
+
+// kind returns the kind of a data type of information_schema.columns
+func kind(dataType string) sqltable.Kind {
+	switch strings.ToLower(dataType) {
+	case "tinyint", "smallint", "mediumint", "int", "bigint", "year":
+		return sqltable.Integer
+	case "float", "double":
+		return sqltable.Float
+	case "decimal":
+		return sqltable.Decimal
+	case "date":
+		return sqltable.Date
+	case "datetime", "timestamp":
+		return sqltable.Timestamp
+	case "json":
+		return sqltable.JSON
+	case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob":
+		return sqltable.Binary
+	}
+	return sqltable.Text
+}
+
+// splitTable returns the database and the name of the table, the database being empty for the current one
+func splitTable(table string) (string, string) {
+	if database, name, ok := strings.Cut(table, "."); ok {
+		return database, name
+	}
+	return "", table
+}
+
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
@@ -0,0 +1,124 @@
+package mysql_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container/mysql"
+	"github.com/jfelipearaujo/testcontainers/pkg/internal/sqlfake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ordersTable answers the queries of the table helpers like MySQL for an orders table with the given rows
+func ordersTable(rows [][]any) sqlfake.Handler {
+	return func(query sqlfake.Query) (sqlfake.Rows, error) {
+		switch {
+		case strings.Contains(query.SQL, "information_schema.columns"):
+			return sqlfake.Rows{
+				Columns: []string{"column_name", "data_type"},
+				Values: [][]any{
+					{"id", "int"},
+					{"paid", "tinyint"},
+					{"total", "decimal"},
+					{"details", "json"},
+					{"created_at", "datetime"},
+				},
+			}, nil
+		case strings.HasPrefix(query.SQL, "SELECT"):
+			return sqlfake.Rows{
+				Columns: []string{"id", "paid", "total", "details", "created_at"},
+				Values:  rows,
+			}, nil
+		}
+		return sqlfake.Rows{}, nil
+	}
+}
+
+func ordersFixture() mysql.TableFixture {
+	return mysql.TableFixture{
+		Table:   "orders",
+		Columns: []string{"id", "paid", "total", "details", "created_at"},
+		Rows: []mysql.Row{
+			{"id": "1", "paid": "true", "total": "10.5", "details": `{"items": 2}`, "created_at": "2024-01-02 10:00:00"},
+			{"id": "2", "paid": "0", "total": "NULL", "details": "NULL", "created_at": "NULL"},
+		},
+	}
+}
+
+func TestInsertRows(t *testing.T) {
+	t.Run("Should convert the values to the column types", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		db := sqlfake.Open(ordersTable(nil))
+		defer db.Close()
+
+		// Act
+		err := mysql.InsertRows(ctx, db.DB, ordersFixture())
+
+		// Assert
+		require.NoError(t, err)
+
+		queries := db.Queries()
+		require.Len(t, queries, 3)
+		assert.Equal(t, "INSERT INTO `orders` (`id`, `paid`, `total`, `details`, `created_at`) VALUES (?, ?, ?, ?, ?)", queries[1].SQL)
+		assert.Equal(t, []any{int64(1), int64(1), "10.5", `{"items": 2}`, time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)}, queries[1].Args)
+		assert.Equal(t, []any{int64(2), int64(0), nil, nil, nil}, queries[2].Args)
+	})
+
+	t.Run("Should return an error for an invalid value", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		db := sqlfake.Open(ordersTable(nil))
+		defer db.Close()
+
+		fixture := ordersFixture()
+		fixture.Rows[0]["paid"] = "maybe"
+
+		// Act
+		err := mysql.InsertRows(ctx, db.DB, fixture)
+
+		// Assert
+		assert.ErrorContains(t, err, "failed to convert row 1 column 'paid'")
+	})
+}
+
+func TestCompareRows(t *testing.T) {
+	t.Run("Should match the values scanned from the database", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		db := sqlfake.Open(ordersTable([][]any{
+			{int64(1), int64(1), []byte("10.50"), []byte(`{"items":2}`), time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)},
+			{int64(2), int64(0), nil, nil, nil},
+		}))
+		defer db.Close()
+
+		// Act
+		err := mysql.CompareRows(ctx, db.DB, ordersFixture(), true)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should report the rows that differ", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		db := sqlfake.Open(ordersTable([][]any{
+			{int64(1), int64(0), []byte("10.50"), []byte(`{"items":3}`), time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)},
+		}))
+		defer db.Close()
+
+		fixture := ordersFixture()
+		fixture.Rows = fixture.Rows[:1]
+
+		// Act
+		err := mysql.CompareRows(ctx, db.DB, fixture, false)
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `paid: 1`)
+		assert.Contains(t, err.Error(), `paid: 0`)
+	})
+}
@@ -0,0 +1,147 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+)
+
+// TruncateOptions is a type that represents the options of TruncateAll
+//
+//	Default options:
+//		Databases: the current database
+//		ExcludedTables: nil
+type TruncateOptions struct {
+	Databases      []string
+	ExcludedTables []string
+}
+
+// TruncateOption is a type that represents a TruncateAll option
+type TruncateOption func(*TruncateOptions)
+
+// WithDatabases is a TruncateOption that sets the databases whose tables will be truncated
+//
+//	Default: the current database
+func WithDatabases(databases ...string) TruncateOption {
+	return func(options *TruncateOptions) {
+		options.Databases = databases
+	}
+}
+
+// WithExcludedTables is a TruncateOption that sets the tables that will NOT be truncated, as "table" or "database.table"
+//
+//	Default: nil
+//
+// Example:
+//
+//	mysql.WithExcludedTables("schema_migrations")
+func WithExcludedTables(tables ...string) TruncateOption {
+	return func(options *TruncateOptions) {
+		options.ExcludedTables = tables
+	}
+}
+
+// TruncateAll truncates all the tables of the database, resetting their AUTO_INCREMENT counters, so each scenario
+// starts with an empty database without restarting the container. The foreign key checks are disabled
+// while truncating, so the tables can be truncated in any order
+//
+// Example:
+//
+//	err := mysql.TruncateAll(ctx, db, mysql.WithExcludedTables("schema_migrations"))
+func TruncateAll(ctx context.Context, db *sql.DB, opts ...TruncateOption) error {
+	options := &TruncateOptions{}
+
+	for _, o := range opts {
+		o(options)
+	}
+
+	tables, err := userTables(ctx, db, options)
+	if err != nil {
+		return err
+	}
+
+	if len(tables) == 0 {
+		return nil
+	}
+
+	// FOREIGN_KEY_CHECKS is a session variable, so every statement must run on the same connection
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get a connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 0"); err != nil {
+		return fmt.Errorf("failed to disable the foreign key checks: %w", err)
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), "SET FOREIGN_KEY_CHECKS = 1")
+
+	for _, table := range tables {
+		if _, err := conn.ExecContext(ctx, "TRUNCATE TABLE "+table); err != nil {
+			return fmt.Errorf("failed to truncate the table %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// TruncateAllHook returns a reset hook that calls TruncateAll, to be registered on a shared group of containers
+//
+// Example:
+//
+//	group := container.BuildGroupContainer(
+//		container.WithDockerContainer(mysqlContainer),
+//		container.WithResetHook(mysql.TruncateAllHook(db, mysql.WithExcludedTables("schema_migrations"))),
+//	)
+func TruncateAllHook(db *sql.DB, opts ...TruncateOption) container.ResetFunc {
+	return func(ctx context.Context) error {
+		return TruncateAll(ctx, db, opts...)
+	}
+}
+
+// userTables returns the quoted names of the tables that will be truncated
+func userTables(ctx context.Context, db *sql.DB, options *TruncateOptions) ([]string, error) {
+	filter := "table_schema = DATABASE()"
+	args := make([]any, len(options.Databases))
+	if len(options.Databases) > 0 {
+		for i, database := range options.Databases {
+			args[i] = database
+		}
+		filter = "table_schema IN (?" + strings.Repeat(", ?", len(options.Databases)-1) + ")"
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT table_schema, table_name
+		FROM information_schema.tables
+		WHERE table_type = 'BASE TABLE'
+		AND `+filter+`
+		ORDER BY table_schema, table_name`,
+		args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the tables: %w", err)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var database, name string
+		if err := rows.Scan(&database, &name); err != nil {
+			return nil, fmt.Errorf("failed to scan the tables: %w", err)
+		}
+
+		if slices.Contains(options.ExcludedTables, name) || slices.Contains(options.ExcludedTables, database+"."+name) {
+			continue
+		}
+
+		tables = append(tables, quoteIdentifier(database)+"."+quoteIdentifier(name))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list the tables: %w", err)
+	}
+
+	return tables, nil
+}
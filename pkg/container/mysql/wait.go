@@ -0,0 +1,27 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+)
+
+// PingStrategy is a wait strategy that waits until the MySQL container answers the SQL ping
+type PingStrategy = container.PingStrategy
+
+// ForPing returns a wait strategy that connects with the given options and pings the database
+//
+//	Default startup timeout: 60 seconds
+//	Default poll interval: 500 milliseconds
+func ForPing(opts ...MySQLOption) *PingStrategy {
+	options := buildOptions(opts...)
+
+	return container.ForPing(options.ExposedPort, func(ctx context.Context, host string, port string) error {
+		db, err := newConnInfo(options, host, port).Open(ctx)
+		if err != nil {
+			return err
+		}
+
+		return db.Close()
+	})
+}
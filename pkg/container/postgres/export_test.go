@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+)
+
+// SetOpenDB replaces the function opening the database reset by the module, until the returned function is called
+func SetOpenDB(open func(ctx context.Context, connInfo ConnInfo) (*sql.DB, error)) (restore func()) {
+	previous := openDB
+	openDB = open
+	return func() {
+		openDB = previous
+	}
+}
+
+// WithPrimary exposes the primary definition to the tests
+func (t *Topology) WithPrimary() container.ContainerOption {
+	return t.withPrimary()
+}
+
+// WithReplica exposes the replica definition to the tests
+func (t *Topology) WithReplica(primaryAlias string) container.ContainerOption {
+	return t.withReplica(primaryAlias)
+}
+
+// NewNode creates a node connected with the given options, as the topology does
+func NewNode(alias string, opts ...PostgresOption) *Node {
+	return &Node{Alias: alias, connOptions: opts}
+}
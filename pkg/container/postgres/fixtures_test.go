@@ -0,0 +1,87 @@
+package postgres_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestReadFixtures(t *testing.T) {
+	t.Run("Should read the tables of a YAML file in order", func(t *testing.T) {
+		// Arrange
+		file := writeFile(t, "fixtures.yaml", `
+users:
+  - id: 1
+    name: John
+    email: null
+  - id: 2
+    name: Jane
+    settings:
+      theme: dark
+orders:
+  - id: 10
+    user_id: 1
+`)
+
+		// Act
+		fixtures, err := postgres.ReadFixtures(file)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, fixtures, 2)
+		assert.Equal(t, "users", fixtures[0].Table)
+		assert.Equal(t, []string{"id", "name", "email", "settings"}, fixtures[0].Columns)
+		assert.Equal(t, postgres.Row{"id": "1", "name": "John", "email": nil}, fixtures[0].Rows[0])
+		assert.Equal(t, `{"theme":"dark"}`, fixtures[0].Rows[1]["settings"])
+		assert.Equal(t, "orders", fixtures[1].Table)
+	})
+
+	t.Run("Should read the tables of a JSON file", func(t *testing.T) {
+		// Arrange
+		file := writeFile(t, "fixtures.json", `{"users": [{"id": 1, "name": "John"}]}`)
+
+		// Act
+		fixtures, err := postgres.ReadFixtures(file)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, fixtures, 1)
+		assert.Equal(t, postgres.Row{"id": "1", "name": "John"}, fixtures[0].Rows[0])
+	})
+
+	t.Run("Should read a CSV file named after the table", func(t *testing.T) {
+		// Arrange
+		file := writeFile(t, "users.csv", "id,name\n1,John\n2,NULL\n")
+
+		// Act
+		fixtures, err := postgres.ReadFixtures(file)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, fixtures, 1)
+		assert.Equal(t, "users", fixtures[0].Table)
+		assert.Equal(t, []string{"id", "name"}, fixtures[0].Columns)
+		assert.Equal(t, postgres.Row{"id": "2", "name": "NULL"}, fixtures[0].Rows[1])
+	})
+
+	t.Run("Should return an error for unsupported formats", func(t *testing.T) {
+		// Arrange
+		file := writeFile(t, "users.txt", "")
+
+		// Act
+		_, err := postgres.ReadFixtures(file)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
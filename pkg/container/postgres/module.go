@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+var _ container.Module = (*Module)(nil)
+
+// openDB opens the database reset by the module, replaced by the tests
+var openDB = func(ctx context.Context, connInfo ConnInfo) (*sql.DB, error) {
+	return connInfo.Open(ctx)
+}
+
+// Module is a type that represents the PostgreSQL module
+type Module struct {
+	opts         []PostgresOption
+	truncateOpts []TruncateOption
+}
+
+// NewModule returns the PostgreSQL module with the given options
+//
+// Example:
+//
+//	module := postgres.NewModule(
+//		postgres.WithDatabase("orders"),
+//		postgres.WithNetwork(ntwrkDefinition),
+//	)
+//
+//	definition := container.NewModuleDefinition(module,
+//		container.WithNetwork(ntwrkDefinition.Alias, network),
+//	)
+func NewModule(opts ...PostgresOption) *Module {
+	return &Module{
+		opts: opts,
+	}
+}
+
+// WithTruncateOptions sets the options given to TruncateAll by the reset of the module, for instance to keep
+// the migrations tables
+//
+// Example:
+//
+//	module := postgres.NewModule(postgres.WithDatabase("orders")).
+//		WithTruncateOptions(postgres.WithExcludedTables("schema_migrations"))
+func (m *Module) WithTruncateOptions(opts ...TruncateOption) *Module {
+	m.truncateOpts = opts
+	return m
+}
+
+// Definition returns the options of the PostgreSQL container definition
+func (m *Module) Definition() []container.ContainerOption {
+	return []container.ContainerOption{
+		WithPostgresContainer(m.opts...),
+	}
+}
+
+// Readiness returns the strategy used to wait for the PostgreSQL container to be ready
+func (m *Module) Readiness() wait.Strategy {
+	return readiness()
+}
+
+// InternalEndpoint returns the connection string of the container for the containers of the same network
+func (m *Module) InternalEndpoint(ctx context.Context, container testcontainers.Container) (string, error) {
+	return BuildInternalConnectionString(ctx, container, m.opts...)
+}
+
+// ExternalEndpoint returns the connection string of the container for the host
+func (m *Module) ExternalEndpoint(ctx context.Context, container testcontainers.Container) (string, error) {
+	return BuildExternalConnectionString(ctx, container, m.opts...)
+}
+
+// Reset truncates the tables of the database with the options set by WithTruncateOptions, see TruncateAll
+func (m *Module) Reset(ctx context.Context, container testcontainers.Container) error {
+	connInfo, err := BuildExternalConnInfo(ctx, container, m.opts...)
+	if err != nil {
+		return err
+	}
+
+	db, err := openDB(ctx, connInfo)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return TruncateAll(ctx, db, m.truncateOpts...)
+}
+
+// EnvForDependents returns the connection string as DATABASE_URL and the libpq environment variables
+func (m *Module) EnvForDependents(ctx context.Context, container testcontainers.Container) (map[string]string, error) {
+	connInfo, err := BuildInternalConnInfo(ctx, container, m.opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"DATABASE_URL": connInfo.URL(),
+		"PGHOST":       connInfo.Host,
+		"PGPORT":       connInfo.Port,
+		"PGDATABASE":   connInfo.Database,
+		"PGUSER":       connInfo.User,
+		"PGPASSWORD":   connInfo.Password,
+		"PGSSLMODE":    connInfo.SSLMode,
+	}, nil
+}
@@ -0,0 +1,118 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/jfelipearaujo/testcontainers/pkg/container/postgres"
+	"github.com/jfelipearaujo/testcontainers/pkg/internal/sqlfake"
+	"github.com/jfelipearaujo/testcontainers/pkg/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// stubContainer is a started container answering only the host and the mapped ports
+type stubContainer struct {
+	testcontainers.Container
+}
+
+func (stubContainer) Host(context.Context) (string, error) {
+	return "localhost", nil
+}
+
+func (stubContainer) MappedPort(_ context.Context, port nat.Port) (nat.Port, error) {
+	return nat.Port("49153/" + port.Proto()), nil
+}
+
+func TestModule(t *testing.T) {
+	t.Run("Should honor the options in the definition", func(t *testing.T) {
+		// Arrange
+		module := postgres.NewModule(
+			postgres.WithDatabase("orders"),
+			postgres.WithUser("app"),
+			postgres.WithPass("secret"),
+		)
+
+		// Act
+		definition := container.NewModuleDefinition(module)
+
+		// Assert
+		assert.Equal(t, map[string]string{
+			"POSTGRES_DB":       "orders",
+			"POSTGRES_USER":     "app",
+			"POSTGRES_PASSWORD": "secret",
+		}, definition.ContainerRequest.Env)
+		assert.NotNil(t, definition.ContainerRequest.WaitingFor)
+	})
+
+	t.Run("Should return the environment of the dependents", func(t *testing.T) {
+		// Arrange
+		module := postgres.NewModule(
+			postgres.WithDatabase("orders"),
+			postgres.WithNetwork(network.NewNetwork(network.WithAlias("db"))),
+		)
+
+		// Act
+		env, err := module.EnvForDependents(context.Background(), nil)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "postgres://postgres:postgres@db:5432/orders?sslmode=disable", env["DATABASE_URL"])
+		assert.Equal(t, "db", env["PGHOST"])
+		assert.Equal(t, "5432", env["PGPORT"])
+		assert.Equal(t, "orders", env["PGDATABASE"])
+	})
+	t.Run("Should truncate the tables of all the schemas on reset", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		module := postgres.NewModule(postgres.WithDatabase("orders"))
+
+		db := sqlfake.Open(catalog([][]any{{"public", "users"}}, nil))
+		defer db.Close()
+
+		var connInfo postgres.ConnInfo
+		restore := postgres.SetOpenDB(func(ctx context.Context, c postgres.ConnInfo) (*sql.DB, error) {
+			connInfo = c
+			return db.DB, nil
+		})
+		defer restore()
+
+		// Act
+		err := module.Reset(ctx, stubContainer{})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "localhost", connInfo.Host)
+		assert.Equal(t, "49153", connInfo.Port)
+		assert.Equal(t, []string{
+			`TRUNCATE TABLE "public"."users" RESTART IDENTITY CASCADE`,
+		}, executed(db))
+	})
+
+	t.Run("Should keep the excluded tables on reset", func(t *testing.T) {
+		// Arrange
+		module := postgres.NewModule().
+			WithTruncateOptions(postgres.WithExcludedTables("schema_migrations"))
+
+		db := sqlfake.Open(catalog([][]any{{"public", "users"}, {"public", "schema_migrations"}}, nil))
+		defer db.Close()
+
+		restore := postgres.SetOpenDB(func(context.Context, postgres.ConnInfo) (*sql.DB, error) {
+			return db.DB, nil
+		})
+		defer restore()
+
+		// Act
+		err := module.Reset(context.Background(), stubContainer{})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			`TRUNCATE TABLE "public"."users" RESTART IDENTITY CASCADE`,
+		}, executed(db))
+	})
+}
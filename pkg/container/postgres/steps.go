@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/cucumber/godog"
+	"github.com/jfelipearaujo/testcontainers/pkg/internal/sqltable"
+)
+
+// Steps is a type that represents the table steps of a PostgreSQL database
+type Steps = sqltable.Steps
+
+// StepsOption is a type that represents a Steps option
+type StepsOption = sqltable.StepsOption
+
+// WithVariables is a StepsOption that sets the function used to resolve the {{name}} placeholders of the table cells
+//
+// Default: nil
+//
+// Example:
+//
+//	postgres.WithVariables(func(ctx context.Context) map[string]any {
+//		currentState := testState.Retrieve(ctx)
+//		return map[string]any{
+//			"user_id": currentState.userId,
+//		}
+//	})
+func WithVariables(variables func(ctx context.Context) map[string]any) StepsOption {
+	return sqltable.WithVariables(variables)
+}
+
+// WithName is a StepsOption that sets the name qualifying the tables and the fixtures in the steps, so the steps
+// of several PostgreSQL databases can be registered on the same scenario
+//
+// Default: "" (unqualified steps)
+//
+// Example:
+//
+//	postgres.WithName("billing") // Given the billing table "invoices" contains:
+func WithName(name string) StepsOption {
+	return sqltable.WithName(name)
+}
+
+// RegisterSteps registers the table steps on the given scenario context
+//
+// The db function must return the database of the current scenario. The registered steps are:
+//
+//	Given the table "users" contains:
+//	Then the table "users" should contain:
+//	Then the table "users" should contain exactly:
+//	Given the fixtures "./testdata/users.yaml" are loaded
+//
+// The first row of a table contains the column names, the cell value NULL represents a SQL NULL
+// and {{name}} placeholders are replaced by the values returned by WithVariables
+func RegisterSteps(ctx *godog.ScenarioContext, db func(ctx context.Context) (*sql.DB, error), opts ...StepsOption) *Steps {
+	return sqltable.RegisterSteps(ctx, dialect{}, db, "", opts...)
+}
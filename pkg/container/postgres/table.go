@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/internal/sqltable"
+	"github.com/lib/pq"
+)
+
+// NullValue is the cell value that represents a SQL NULL in tables and fixtures
+const NullValue string = sqltable.NullValue
+
+// Row is a type that represents a row of a table, indexed by the column name
+type Row = sqltable.Row
+
+// TableFixture is a type that represents the rows that will be inserted into a table
+type TableFixture = sqltable.Fixture
+
+// InsertRows inserts the rows into the given table, converting the values to the column types
+//
+// String values are converted to the type of the column, nil values are inserted as NULL
+func InsertRows(ctx context.Context, db *sql.DB, fixture TableFixture) error {
+	return sqltable.InsertRows(ctx, db, dialect{}, fixture)
+}
+
+// CompareRows compares the expected rows with the rows stored in the given table
+//
+// Only the columns of the fixture are compared. When exact is true the table must contain
+// only the expected rows, otherwise the expected rows must be a subset of the table rows.
+// The returned error contains a readable diff of the rows
+func CompareRows(ctx context.Context, db *sql.DB, expected TableFixture, exact bool) error {
+	return sqltable.CompareRows(ctx, db, dialect{}, expected, exact)
+}
+
+// dialect is the PostgreSQL flavor of the table helpers
+type dialect struct{}
+
+func (dialect) Placeholder(n int) string {
+	return fmt.Sprintf("$%d", n)
+}
+
+func (dialect) QuoteIdentifier(name string) string {
+	return pq.QuoteIdentifier(name)
+}
+
+func (dialect) QuoteTable(table string) string {
+	schema, name := splitTable(table)
+	return pq.QuoteIdentifier(schema) + "." + pq.QuoteIdentifier(name)
+}
+
+func (dialect) ColumnTypes(ctx context.Context, db *sql.DB, table string) (map[string]sqltable.Kind, error) {
+	schema, name := splitTable(table)
+
+	return sqltable.QueryColumnTypes(ctx, db, table, kind,
+		"SELECT column_name, data_type FROM information_schema.columns WHERE table_schema = $1 AND table_name = $2",
+		schema,
+		name)
+}
+
+func (dialect) ConvertValue(kind sqltable.Kind, raw any) (any, error) {
+	return sqltable.ConvertValue(kind, raw)
+}
+
+// kind returns the kind of a data type of information_schema.columns
+func kind(dataType string) sqltable.Kind {
+	switch {
+	case dataType == "smallint", dataType == "integer", dataType == "bigint":
+		return sqltable.Integer
+	case dataType == "real", dataType == "double precision":
+		return sqltable.Float
+	case dataType == "numeric":
+		return sqltable.Decimal
+	case dataType == "boolean":
+		return sqltable.Boolean
+	case dataType == "date":
+		return sqltable.Date
+	case strings.HasPrefix(dataType, "timestamp"):
+		return sqltable.Timestamp
+	case dataType == "json", dataType == "jsonb":
+		return sqltable.JSON
+	case dataType == "bytea":
+		return sqltable.Binary
+	}
+	return sqltable.Text
+}
+
+func splitTable(table string) (string, string) {
+	if schema, name, ok := strings.Cut(table, "."); ok {
+		return schema, name
+	}
+	return "public", table
+}
@@ -0,0 +1,414 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/jfelipearaujo/testcontainers/pkg/network"
+	"github.com/lib/pq"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	ReplicationUser string = "replicator"
+	ReplicationPass string = "replicator"
+)
+
+// TopologyOptions is a type that represents the options of a replication topology
+//
+//	Default options:
+//		Image: "postgres:16"
+//		Replicas: 1
+//		AliasPrefix: "postgres"
+//		ReplicationUser: "replicator"
+//		ReplicationPass: "replicator"
+//		StartupTimeout: "60 seconds"
+type TopologyOptions struct {
+	Image           string
+	Replicas        int
+	AliasPrefix     string
+	ReplicationUser string
+	ReplicationPass string
+	StartupTimeout  time.Duration
+	ConnOptions     []PostgresOption
+}
+
+// TopologyOption is a type that represents a replication topology option
+type TopologyOption func(*TopologyOptions)
+
+// WithTopologyImage is a TopologyOption that sets the image of the nodes, it must be a Debian based PostgreSQL image
+//
+//	Default: "postgres:16"
+func WithTopologyImage(image string) TopologyOption {
+	return func(options *TopologyOptions) {
+		options.Image = image
+	}
+}
+
+// WithReplicas is a TopologyOption that sets the number of streaming replicas
+//
+//	Default: 1
+func WithReplicas(replicas int) TopologyOption {
+	return func(options *TopologyOptions) {
+		options.Replicas = replicas
+	}
+}
+
+// WithAliasPrefix is a TopologyOption that sets the prefix of the network aliases of the nodes
+//
+//	Default: "postgres" (postgres-primary, postgres-replica-1, ...)
+func WithAliasPrefix(prefix string) TopologyOption {
+	return func(options *TopologyOptions) {
+		options.AliasPrefix = prefix
+	}
+}
+
+// WithReplicationUser is a TopologyOption that sets the credentials used by the replicas to stream from the primary
+//
+//	Default: "replicator" / "replicator"
+func WithReplicationUser(user string, pass string) TopologyOption {
+	return func(options *TopologyOptions) {
+		options.ReplicationUser = user
+		options.ReplicationPass = pass
+	}
+}
+
+// WithTopologyStartupTimeout is a TopologyOption that sets the startup timeout of each node
+//
+//	Default: 60 seconds
+func WithTopologyStartupTimeout(timeout time.Duration) TopologyOption {
+	return func(options *TopologyOptions) {
+		options.StartupTimeout = timeout
+	}
+}
+
+// WithConnOptions is a TopologyOption that sets the database, user and password of the nodes
+//
+//	Default: postgres_db / postgres / postgres
+func WithConnOptions(opts ...PostgresOption) TopologyOption {
+	return func(options *TopologyOptions) {
+		options.ConnOptions = opts
+	}
+}
+
+// Node is a type that represents a node of a replication topology
+type Node struct {
+	Alias     string
+	Container testcontainers.Container
+
+	connOptions []PostgresOption
+}
+
+// InternalConnInfo returns the connection information of the node for containers in the same network
+func (node *Node) InternalConnInfo() ConnInfo {
+	options := buildOptions(node.connOptions...)
+	return newConnInfo(options, node.Alias, nat.Port(options.ExposedPort).Port())
+}
+
+// ExternalConnInfo returns the connection information of the node for clients running on the host
+func (node *Node) ExternalConnInfo(ctx context.Context) (ConnInfo, error) {
+	return BuildExternalConnInfo(ctx, node.Container, node.connOptions...)
+}
+
+// Topology is a type that represents a primary and N streaming replicas sharing a network
+type Topology struct {
+	Primary  *Node
+	Replicas []*Node
+
+	network *network.Network
+	options *TopologyOptions
+}
+
+// NewTopology creates a new replication topology definition that will be started on the given network
+//
+// Example:
+//
+//	topology := postgres.NewTopology(ntwrkDefinition, postgres.WithReplicas(2))
+//	if err := topology.Build(ctx); err != nil {
+//		return ctx, err
+//	}
+//
+//	containers[sc.Id] = container.BuildGroupContainer(
+//		container.WithDockerContainer(topology.Containers()...),
+//	)
+func NewTopology(ntw *network.Network, opts ...TopologyOption) *Topology {
+	options := &TopologyOptions{
+		Image:           "postgres:16",
+		Replicas:        1,
+		AliasPrefix:     "postgres",
+		ReplicationUser: ReplicationUser,
+		ReplicationPass: ReplicationPass,
+		StartupTimeout:  60 * time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return &Topology{
+		network: ntw,
+		options: options,
+	}
+}
+
+// Build starts the primary, then the replicas, and waits for the replicas to catch up with the primary
+//
+// When the topology fails to start, the nodes already started are terminated.
+func (t *Topology) Build(ctx context.Context) error {
+	if err := t.build(ctx); err != nil {
+		return errors.Join(err, t.terminate(context.WithoutCancel(ctx)))
+	}
+
+	return nil
+}
+
+func (t *Topology) build(ctx context.Context) error {
+	if t.options.Replicas < 0 {
+		return fmt.Errorf("the number of replicas can not be negative, got %d", t.options.Replicas)
+	}
+
+	dockerNetwork, err := t.network.Build(ctx)
+	if err != nil {
+		return err
+	}
+
+	primaryAlias := t.options.AliasPrefix + "-primary"
+
+	primary, err := container.NewContainerDefinition(
+		container.WithNetwork(primaryAlias, dockerNetwork),
+		WithPostgresContainer(t.options.ConnOptions...),
+		t.withPrimary(),
+	).BuildContainer(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start the primary: %w", err)
+	}
+
+	t.Primary = &Node{
+		Alias:       primaryAlias,
+		Container:   primary,
+		connOptions: t.options.ConnOptions,
+	}
+
+	for i := 1; i <= t.options.Replicas; i++ {
+		alias := fmt.Sprintf("%s-replica-%d", t.options.AliasPrefix, i)
+
+		replica, err := container.NewContainerDefinition(
+			container.WithNetwork(alias, dockerNetwork),
+			WithPostgresContainer(t.options.ConnOptions...),
+			t.withReplica(primaryAlias),
+		).BuildContainer(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to start the replica %d: %w", i, err)
+		}
+
+		t.Replicas = append(t.Replicas, &Node{
+			Alias:       alias,
+			Container:   replica,
+			connOptions: t.options.ConnOptions,
+		})
+	}
+
+	return t.WaitForReplicas(ctx, t.options.StartupTimeout)
+}
+
+// terminate terminates the nodes already started and forgets them
+func (t *Topology) terminate(ctx context.Context) error {
+	var errs []error
+	for _, node := range t.Containers() {
+		if err := node.Terminate(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	t.Primary = nil
+	t.Replicas = nil
+
+	return errors.Join(errs...)
+}
+
+// Containers returns the containers of all the nodes, to be destroyed with the group
+func (t *Topology) Containers() []testcontainers.Container {
+	var containers []testcontainers.Container
+
+	if t.Primary != nil {
+		containers = append(containers, t.Primary.Container)
+	}
+
+	for _, replica := range t.Replicas {
+		containers = append(containers, replica.Container)
+	}
+
+	return containers
+}
+
+// WaitForReplicas waits until every replica has replayed the WAL written by the primary up to now
+func (t *Topology) WaitForReplicas(ctx context.Context, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	primary, err := t.openNode(ctx, t.Primary)
+	if err != nil {
+		return err
+	}
+	defer primary.Close()
+
+	var lsn string
+	if err := primary.QueryRowContext(ctx, "SELECT pg_current_wal_lsn()::text").Scan(&lsn); err != nil {
+		return fmt.Errorf("failed to read the primary WAL position: %w", err)
+	}
+
+	for _, replica := range t.Replicas {
+		if err := t.waitForReplica(ctx, replica, lsn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PauseReplication pauses the WAL replay of the given replica (starting at 0), so its reads lag behind the primary
+func (t *Topology) PauseReplication(ctx context.Context, replica int) error {
+	return t.execOnReplica(ctx, replica, "SELECT pg_wal_replay_pause()")
+}
+
+// ResumeReplication resumes the WAL replay of the given replica (starting at 0)
+func (t *Topology) ResumeReplication(ctx context.Context, replica int) error {
+	return t.execOnReplica(ctx, replica, "SELECT pg_wal_replay_resume()")
+}
+
+func (t *Topology) execOnReplica(ctx context.Context, replica int, query string) error {
+	if replica < 0 || replica >= len(t.Replicas) {
+		return fmt.Errorf("replica %d not found", replica)
+	}
+
+	db, err := t.openNode(ctx, t.Replicas[replica])
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to execute '%s' on %s: %w", query, t.Replicas[replica].Alias, err)
+	}
+
+	return nil
+}
+
+func (t *Topology) waitForReplica(ctx context.Context, replica *Node, lsn string) error {
+	db, err := t.openNode(ctx, replica)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		var caughtUp bool
+		err := db.QueryRowContext(ctx,
+			"SELECT COALESCE(pg_last_wal_replay_lsn() >= $1::pg_lsn, false)",
+			lsn).Scan(&caughtUp)
+		if err == nil && caughtUp {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("replica %s did not catch up with the primary: %w", replica.Alias, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *Topology) openNode(ctx context.Context, node *Node) (*sql.DB, error) {
+	connInfo, err := node.ExternalConnInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return connInfo.Open(ctx)
+}
+
+// withPrimary configures the container to accept streaming replication connections
+func (t *Topology) withPrimary() container.ContainerOption {
+	options := buildOptions(t.options.ConnOptions...)
+	port := nat.Port(options.ExposedPort).Port()
+
+	script := fmt.Sprintf(`#!/bin/sh
+set -e
+psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "$POSTGRES_DB" <<'EOSQL'
+CREATE ROLE %s WITH REPLICATION LOGIN PASSWORD %s;
+EOSQL
+echo %s >> "$PGDATA/pg_hba.conf"
+`,
+		pq.QuoteIdentifier(t.options.ReplicationUser),
+		pq.QuoteLiteral(t.options.ReplicationPass),
+		shellQuote("host replication "+pq.QuoteIdentifier(t.options.ReplicationUser)+" all scram-sha-256"))
+
+	return func(c *container.Container) {
+		c.ContainerRequest.Image = t.options.Image
+		c.ContainerRequest.Env = map[string]string{
+			"POSTGRES_DB":       options.Database,
+			"POSTGRES_USER":     options.User,
+			"POSTGRES_PASSWORD": options.Pass,
+		}
+		c.ContainerRequest.Cmd = []string{
+			"postgres",
+			"-c", "wal_level=replica",
+			"-c", "max_wal_senders=10",
+			"-c", "max_replication_slots=10",
+			"-c", "hot_standby=on",
+			"-c", "port=" + port,
+		}
+		c.ContainerRequest.Files = append(c.ContainerRequest.Files, testcontainers.ContainerFile{
+			Reader:            strings.NewReader(script),
+			ContainerFilePath: BasePath + "/00-replication.sh",
+			FileMode:          0755,
+		})
+		c.ContainerRequest.WaitingFor = wait.
+			ForLog("database system is ready to accept connections").
+			WithOccurrence(2).
+			WithStartupTimeout(t.options.StartupTimeout)
+	}
+}
+
+// withReplica configures the container to clone the primary with pg_basebackup and start as a hot standby
+func (t *Topology) withReplica(primaryAlias string) container.ContainerOption {
+	options := buildOptions(t.options.ConnOptions...)
+
+	script := fmt.Sprintf(`set -e
+until pg_isready -h %[1]s -p %[2]s; do sleep 1; done
+rm -rf "$PGDATA"/*
+pg_basebackup -h %[1]s -p %[2]s -D "$PGDATA" -Fp -Xs -R
+chown -R postgres:postgres "$PGDATA"
+chmod 0700 "$PGDATA"
+exec gosu postgres postgres -c hot_standby=on -c port=%[2]s
+`, primaryAlias, nat.Port(options.ExposedPort).Port())
+
+	return func(c *container.Container) {
+		c.ContainerRequest.Image = t.options.Image
+		c.ContainerRequest.Env = map[string]string{
+			"PGDATA":     "/var/lib/postgresql/data",
+			"PGUSER":     t.options.ReplicationUser,
+			"PGPASSWORD": t.options.ReplicationPass,
+		}
+		c.ContainerRequest.Entrypoint = []string{"/bin/sh", "-c"}
+		c.ContainerRequest.Cmd = []string{script}
+		c.ContainerRequest.WaitingFor = wait.
+			ForLog("database system is ready to accept read-only connections").
+			WithStartupTimeout(t.options.StartupTimeout)
+	}
+}
+
+// shellQuote quotes the value as a single argument of a POSIX shell
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'"'"'`) + "'"
+}
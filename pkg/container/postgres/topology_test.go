@@ -0,0 +1,165 @@
+package postgres_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/jfelipearaujo/testcontainers/pkg/container/postgres"
+	"github.com/jfelipearaujo/testcontainers/pkg/internal/containerfake"
+	"github.com/jfelipearaujo/testcontainers/pkg/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopologyWithPrimary(t *testing.T) {
+	t.Run("Should create the replication user and listen on the exposed port", func(t *testing.T) {
+		// Arrange
+		topology := postgres.NewTopology(network.NewNetwork(),
+			postgres.WithTopologyImage("postgres:15"),
+			postgres.WithReplicationUser("streamer", "s3cr'et"),
+			postgres.WithConnOptions(
+				postgres.WithDatabase("orders"),
+				postgres.WithUser("app"),
+				postgres.WithPass("secret"),
+				postgres.WithExposedPort("6432"),
+			),
+		)
+		definition := &container.Container{}
+
+		// Act
+		topology.WithPrimary()(definition)
+
+		// Assert
+		assert.Equal(t, "postgres:15", definition.ContainerRequest.Image)
+		assert.Equal(t, map[string]string{
+			"POSTGRES_DB":       "orders",
+			"POSTGRES_USER":     "app",
+			"POSTGRES_PASSWORD": "secret",
+		}, definition.ContainerRequest.Env)
+		assert.Equal(t, []string{
+			"postgres",
+			"-c", "wal_level=replica",
+			"-c", "max_wal_senders=10",
+			"-c", "max_replication_slots=10",
+			"-c", "hot_standby=on",
+			"-c", "port=6432",
+		}, definition.ContainerRequest.Cmd)
+
+		require.Len(t, definition.ContainerRequest.Files, 1)
+		file := definition.ContainerRequest.Files[0]
+		assert.Equal(t, postgres.BasePath+"/00-replication.sh", file.ContainerFilePath)
+		assert.Equal(t, int64(0755), file.FileMode)
+
+		script, err := io.ReadAll(file.Reader)
+		require.NoError(t, err)
+		assert.Contains(t, string(script), `CREATE ROLE "streamer" WITH REPLICATION LOGIN PASSWORD 's3cr''et';`)
+		assert.Contains(t, string(script), `echo 'host replication "streamer" all scram-sha-256' >> "$PGDATA/pg_hba.conf"`)
+	})
+
+	t.Run("Should quote the replication user in the shell", func(t *testing.T) {
+		// Arrange
+		topology := postgres.NewTopology(network.NewNetwork(),
+			postgres.WithReplicationUser("o'brien $(id)", "secret"),
+		)
+		definition := &container.Container{}
+
+		// Act
+		topology.WithPrimary()(definition)
+
+		// Assert
+		require.Len(t, definition.ContainerRequest.Files, 1)
+		script, err := io.ReadAll(definition.ContainerRequest.Files[0].Reader)
+		require.NoError(t, err)
+		assert.Contains(t, string(script), `CREATE ROLE "o'brien $(id)" WITH REPLICATION LOGIN PASSWORD 'secret';`)
+		assert.Contains(t, string(script), `echo 'host replication "o'"'"'brien $(id)" all scram-sha-256' >> "$PGDATA/pg_hba.conf"`)
+	})
+}
+
+func TestTopologyWithReplica(t *testing.T) {
+	t.Run("Should clone the primary on the exposed port with the replication user", func(t *testing.T) {
+		// Arrange
+		topology := postgres.NewTopology(network.NewNetwork(),
+			postgres.WithReplicationUser("streamer", "secret"),
+			postgres.WithConnOptions(postgres.WithExposedPort("6432")),
+		)
+		definition := &container.Container{}
+
+		// Act
+		topology.WithReplica("postgres-primary")(definition)
+
+		// Assert
+		assert.Equal(t, "postgres:16", definition.ContainerRequest.Image)
+		assert.Equal(t, map[string]string{
+			"PGDATA":     "/var/lib/postgresql/data",
+			"PGUSER":     "streamer",
+			"PGPASSWORD": "secret",
+		}, definition.ContainerRequest.Env)
+		assert.Equal(t, []string{"/bin/sh", "-c"}, definition.ContainerRequest.Entrypoint)
+
+		require.Len(t, definition.ContainerRequest.Cmd, 1)
+		script := definition.ContainerRequest.Cmd[0]
+		assert.Contains(t, script, "until pg_isready -h postgres-primary -p 6432; do sleep 1; done")
+		assert.Contains(t, script, `pg_basebackup -h postgres-primary -p 6432 -D "$PGDATA" -Fp -Xs -R`)
+		assert.Contains(t, script, "exec gosu postgres postgres -c hot_standby=on -c port=6432")
+	})
+
+	t.Run("Should use the default port and replication user", func(t *testing.T) {
+		// Arrange
+		topology := postgres.NewTopology(network.NewNetwork())
+		definition := &container.Container{}
+
+		// Act
+		topology.WithReplica("postgres-primary")(definition)
+
+		// Assert
+		assert.Equal(t, "replicator", definition.ContainerRequest.Env["PGUSER"])
+		assert.Contains(t, definition.ContainerRequest.Cmd[0], "pg_basebackup -h postgres-primary -p 5432 -D")
+	})
+}
+
+func TestNodeInternalConnInfo(t *testing.T) {
+	t.Run("Should connect to the alias on the exposed port", func(t *testing.T) {
+		// Arrange
+		node := postgres.NewNode("postgres-replica-1", postgres.WithExposedPort("6432"))
+
+		// Act
+		connInfo := node.InternalConnInfo()
+
+		// Assert
+		assert.Equal(t, "postgres-replica-1", connInfo.Host)
+		assert.Equal(t, "6432", connInfo.Port)
+	})
+}
+
+func TestTopologyBuild(t *testing.T) {
+	t.Run("Should refuse a negative number of replicas", func(t *testing.T) {
+		// Arrange
+		topology := postgres.NewTopology(network.NewNetwork(), postgres.WithReplicas(-1))
+
+		// Act
+		err := topology.Build(context.Background())
+
+		// Assert
+		assert.ErrorContains(t, err, "the number of replicas can not be negative, got -1")
+		assert.Empty(t, topology.Containers())
+	})
+
+	t.Run("Should terminate the nodes already started on failure", func(t *testing.T) {
+		// Arrange
+		primary, replica := &containerfake.Container{}, &containerfake.Container{}
+		topology := postgres.NewTopology(network.NewNetwork(), postgres.WithReplicas(-1))
+		topology.Primary = &postgres.Node{Alias: "postgres-primary", Container: primary}
+		topology.Replicas = []*postgres.Node{{Alias: "postgres-replica-1", Container: replica}}
+
+		// Act
+		err := topology.Build(context.Background())
+
+		// Assert
+		assert.Error(t, err)
+		assert.True(t, primary.Terminated)
+		assert.True(t, replica.Terminated)
+		assert.Empty(t, topology.Containers())
+	})
+}
@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/lib/pq"
+)
+
+// TruncateOptions is a type that represents the options of TruncateAll
+//
+//	Default options:
+//		Schemas: all the schemas except pg_catalog and information_schema
+//		ExcludedTables: nil
+type TruncateOptions struct {
+	Schemas        []string
+	ExcludedTables []string
+}
+
+// TruncateOption is a type that represents a TruncateAll option
+type TruncateOption func(*TruncateOptions)
+
+// WithSchemas is a TruncateOption that sets the schemas whose tables will be truncated
+//
+//	Default: all the schemas except pg_catalog and information_schema
+func WithSchemas(schemas ...string) TruncateOption {
+	return func(options *TruncateOptions) {
+		options.Schemas = schemas
+	}
+}
+
+// WithExcludedTables is a TruncateOption that sets the tables that will NOT be truncated, as "table" or "schema.table"
+//
+//	Default: nil
+//
+// Example:
+//
+//	postgres.WithExcludedTables("schema_migrations")
+func WithExcludedTables(tables ...string) TruncateOption {
+	return func(options *TruncateOptions) {
+		options.ExcludedTables = tables
+	}
+}
+
+// TruncateAll truncates all the user tables of the database, restarting their identities, and resets the
+// standalone sequences, so each scenario starts with an empty database without restarting the container.
+// The tables are truncated with CASCADE, so an excluded table that references a truncated table is also emptied.
+// The tables owned by an extension, like the spatial_ref_sys table of PostGIS, are never truncated
+//
+// Example:
+//
+//	err := postgres.TruncateAll(ctx, db, postgres.WithExcludedTables("schema_migrations"))
+func TruncateAll(ctx context.Context, db *sql.DB, opts ...TruncateOption) error {
+	options := &TruncateOptions{
+		Schemas: []string{},
+	}
+
+	for _, o := range opts {
+		o(options)
+	}
+
+	tables, err := userTables(ctx, db, options)
+	if err != nil {
+		return err
+	}
+
+	if len(tables) > 0 {
+		query := fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY CASCADE", strings.Join(tables, ", "))
+		if _, err := db.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf("failed to truncate the tables: %w", err)
+		}
+	}
+
+	sequences, err := standaloneSequences(ctx, db, options)
+	if err != nil {
+		return err
+	}
+
+	for _, sequence := range sequences {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("ALTER SEQUENCE %s RESTART", sequence)); err != nil {
+			return fmt.Errorf("failed to reset the sequence %s: %w", sequence, err)
+		}
+	}
+
+	return nil
+}
+
+// TruncateAllHook returns a reset hook that calls TruncateAll, to be registered on a shared group of containers
+//
+// Example:
+//
+//	group := container.BuildGroupContainer(
+//		container.WithDockerContainer(pgContainer),
+//		container.WithResetHook(postgres.TruncateAllHook(db, postgres.WithExcludedTables("schema_migrations"))),
+//	)
+func TruncateAllHook(db *sql.DB, opts ...TruncateOption) container.ResetFunc {
+	return func(ctx context.Context) error {
+		return TruncateAll(ctx, db, opts...)
+	}
+}
+
+// userTables returns the quoted names of the tables that will be truncated
+func userTables(ctx context.Context, db *sql.DB, options *TruncateOptions) ([]string, error) {
+	// a nil schema list is bound as NULL, so the cardinality is coalesced to keep the default of all the schemas
+	rows, err := db.QueryContext(ctx, `
+		SELECT n.nspname, c.relname
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind IN ('r', 'p')
+		AND NOT c.relispartition
+		AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+		AND n.nspname NOT LIKE 'pg_toast%'
+		AND (COALESCE(cardinality($1::text[]), 0) = 0 OR n.nspname = ANY($1))
+		AND NOT EXISTS (
+			SELECT 1 FROM pg_depend d
+			WHERE d.classid = 'pg_class'::regclass AND d.objid = c.oid AND d.deptype = 'e'
+		)
+		ORDER BY n.nspname, c.relname`,
+		pq.Array(options.Schemas))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the tables: %w", err)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var schema, name string
+		if err := rows.Scan(&schema, &name); err != nil {
+			return nil, fmt.Errorf("failed to scan the tables: %w", err)
+		}
+
+		if slices.Contains(options.ExcludedTables, name) || slices.Contains(options.ExcludedTables, schema+"."+name) {
+			continue
+		}
+
+		tables = append(tables, pq.QuoteIdentifier(schema)+"."+pq.QuoteIdentifier(name))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list the tables: %w", err)
+	}
+
+	return tables, nil
+}
+
+// standaloneSequences returns the quoted names of the sequences that are not owned by a column,
+// the owned ones being restarted by TRUNCATE ... RESTART IDENTITY
+func standaloneSequences(ctx context.Context, db *sql.DB, options *TruncateOptions) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT n.nspname, c.relname
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind = 'S'
+		AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+		AND (COALESCE(cardinality($1::text[]), 0) = 0 OR n.nspname = ANY($1))
+		AND NOT EXISTS (
+			SELECT 1 FROM pg_depend d
+			WHERE d.classid = 'pg_class'::regclass AND d.objid = c.oid AND d.deptype IN ('a', 'i', 'e')
+		)
+		ORDER BY n.nspname, c.relname`,
+		pq.Array(options.Schemas))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the sequences: %w", err)
+	}
+	defer rows.Close()
+
+	var sequences []string
+	for rows.Next() {
+		var schema, name string
+		if err := rows.Scan(&schema, &name); err != nil {
+			return nil, fmt.Errorf("failed to scan the sequences: %w", err)
+		}
+		sequences = append(sequences, pq.QuoteIdentifier(schema)+"."+pq.QuoteIdentifier(name))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list the sequences: %w", err)
+	}
+
+	return sequences, nil
+}
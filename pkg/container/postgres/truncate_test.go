@@ -0,0 +1,110 @@
+package postgres_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container/postgres"
+	"github.com/jfelipearaujo/testcontainers/pkg/internal/sqlfake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// catalog answers the catalog queries of TruncateAll like PostgreSQL, a NULL schema list matching nothing
+func catalog(tables [][]any, sequences [][]any) sqlfake.Handler {
+	return func(query sqlfake.Query) (sqlfake.Rows, error) {
+		if !strings.Contains(query.SQL, "FROM pg_class") || query.Args[0] == nil {
+			return sqlfake.Rows{}, nil
+		}
+
+		values := tables
+		if strings.Contains(query.SQL, "relkind = 'S'") {
+			values = sequences
+		}
+
+		return sqlfake.Rows{Columns: []string{"nspname", "relname"}, Values: values}, nil
+	}
+}
+
+func executed(db *sqlfake.DB) []string {
+	var statements []string
+	for _, query := range db.Queries() {
+		if !strings.Contains(query.SQL, "SELECT") {
+			statements = append(statements, query.SQL)
+		}
+	}
+	return statements
+}
+
+func TestTruncateAll(t *testing.T) {
+	t.Run("Should truncate the public tables with the default options", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		db := sqlfake.Open(catalog(
+			[][]any{{"public", "orders"}, {"public", "users"}},
+			[][]any{{"public", "invoice_number"}},
+		))
+		defer db.Close()
+
+		// Act
+		err := postgres.TruncateAll(ctx, db.DB)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "{}", db.Queries()[0].Args[0])
+		assert.Equal(t, []string{
+			`TRUNCATE TABLE "public"."orders", "public"."users" RESTART IDENTITY CASCADE`,
+			`ALTER SEQUENCE "public"."invoice_number" RESTART`,
+		}, executed(db))
+	})
+
+	t.Run("Should keep the default schemas when no schema is given", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		db := sqlfake.Open(catalog([][]any{{"public", "users"}}, nil))
+		defer db.Close()
+
+		// Act
+		err := postgres.TruncateAll(ctx, db.DB, postgres.WithSchemas())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Contains(t, db.Queries()[0].SQL, "COALESCE(cardinality($1::text[]), 0) = 0")
+	})
+
+	t.Run("Should filter the schemas and skip the excluded and extension tables", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		db := sqlfake.Open(catalog([][]any{{"app", "schema_migrations"}, {"app", "users"}}, nil))
+		defer db.Close()
+
+		// Act
+		err := postgres.TruncateAll(ctx, db.DB,
+			postgres.WithSchemas("app"),
+			postgres.WithExcludedTables("schema_migrations"),
+		)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "{\"app\"}", db.Queries()[0].Args[0])
+		assert.Contains(t, db.Queries()[0].SQL, "d.deptype = 'e'")
+		assert.Equal(t, []string{
+			`TRUNCATE TABLE "app"."users" RESTART IDENTITY CASCADE`,
+		}, executed(db))
+	})
+
+	t.Run("Should not truncate anything when there is no table", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		db := sqlfake.Open(nil)
+		defer db.Close()
+
+		// Act
+		err := postgres.TruncateAll(ctx, db.DB)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, executed(db))
+	})
+}
@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/lib/pq"
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+)
+
+const (
+	PostGISImage   string = "postgis/postgis:16-3.4"
+	PgVectorImage  string = "pgvector/pgvector:pg16"
+	TimescaleImage string = "timescale/timescaledb:2.14.2-pg16"
+	ConfigFilePath string = "/etc/postgresql/postgresql.conf"
+)
+
+// WithPostGIS is a PostgresOption that uses a PostGIS image and creates the postgis extension after startup
+//
+//	DockerImage: "postgis/postgis:16-3.4"
+//	Extensions: "postgis"
+func WithPostGIS() PostgresOption {
+	return withVariant(PostGISImage, "postgis")
+}
+
+// WithPgVector is a PostgresOption that uses a pgvector image and creates the vector extension after startup
+//
+//	DockerImage: "pgvector/pgvector:pg16"
+//	Extensions: "vector"
+func WithPgVector() PostgresOption {
+	return withVariant(PgVectorImage, "vector")
+}
+
+// WithTimescale is a PostgresOption that uses a TimescaleDB image and creates the timescaledb extension after startup
+//
+//	DockerImage: "timescale/timescaledb:2.14.2-pg16"
+//	Extensions: "timescaledb"
+func WithTimescale() PostgresOption {
+	return withVariant(TimescaleImage, "timescaledb")
+}
+
+func withVariant(image string, extension string) PostgresOption {
+	return func(options *Options) {
+		options.Image = image
+		WithExtensions(extension)(options)
+	}
+}
+
+// WithExtensions is a PostgresOption that creates the given extensions in the database once the container is ready,
+// the extensions must be available in the image
+//
+//	Default: nil
+func WithExtensions(extensions ...string) PostgresOption {
+	return func(options *Options) {
+		options.Extensions = append(options.Extensions, extensions...)
+	}
+}
+
+// WithServerConfig is a PostgresOption that sets the server configuration, rendered as "-c key=value" arguments
+//
+//	Default: nil
+//
+// Example:
+//
+//	postgres.WithServerConfig(map[string]string{
+//		"max_connections": "200",
+//		"log_statement":   "all",
+//	})
+func WithServerConfig(config map[string]string) PostgresOption {
+	return func(options *Options) {
+		if options.ServerConfig == nil {
+			options.ServerConfig = make(map[string]string, len(config))
+		}
+
+		for key, value := range config {
+			options.ServerConfig[key] = value
+		}
+	}
+}
+
+// WithServerConfigFile is a PostgresOption that mounts the given postgresql.conf and starts the server with it,
+// the file replaces the default configuration so it must also set listen_addresses = '*'
+//
+//	Default: ""
+func WithServerConfigFile(file string) PostgresOption {
+	return func(options *Options) {
+		options.ServerConfigFile = file
+	}
+}
+
+// serverCmd returns the command starting the server with the configuration, or nil to keep the command of the image
+func serverCmd(options *Options) []string {
+	config := make(map[string]string, len(options.ServerConfig)+1)
+	for key, value := range options.ServerConfig {
+		config[key] = value
+	}
+	if options.ServerConfigFile != "" {
+		config["config_file"] = ConfigFilePath
+	}
+
+	if len(config) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	cmd := []string{"postgres"}
+	for _, key := range keys {
+		cmd = append(cmd, "-c", fmt.Sprintf("%s=%s", key, config[key]))
+	}
+
+	return cmd
+}
+
+// createExtensions returns the hook creating the extensions once the server is ready
+func createExtensions(options *Options) testcontainers.ContainerLifecycleHooks {
+	return testcontainers.ContainerLifecycleHooks{
+		PostReadies: []testcontainers.ContainerHook{
+			func(ctx context.Context, target testcontainers.Container) error {
+				for _, extension := range options.Extensions {
+					query := fmt.Sprintf("CREATE EXTENSION IF NOT EXISTS %s", pq.QuoteIdentifier(extension))
+					if err := execSQL(ctx, target, options.User, options.Database, query); err != nil {
+						return fmt.Errorf("failed to create the extension '%s': %w", extension, err)
+					}
+				}
+
+				return nil
+			},
+		},
+	}
+}
+
+// execSQL executes the query with psql inside the container
+func execSQL(ctx context.Context, target testcontainers.Container, user string, database string, query string) error {
+	cmd := []string{"psql", "-v", "ON_ERROR_STOP=1", "-U", user, "-d", database, "-c", query}
+
+	code, reader, err := target.Exec(ctx, cmd, tcexec.Multiplexed())
+	if err != nil {
+		return err
+	}
+
+	if code != 0 {
+		output, _ := io.ReadAll(reader)
+		return fmt.Errorf("psql exited with code %d: %s", code, output)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package postgres_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/jfelipearaujo/testcontainers/pkg/container/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariants(t *testing.T) {
+	t.Run("Should render the server config as sorted arguments", func(t *testing.T) {
+		// Arrange
+		config := map[string]string{
+			"max_connections": "200",
+			"log_statement":   "all",
+		}
+
+		// Act
+		definition := container.NewContainerDefinition(
+			postgres.WithPostgresContainer(postgres.WithServerConfig(config)),
+		)
+
+		// Assert
+		assert.Equal(t, []string{"postgres", "-c", "log_statement=all", "-c", "max_connections=200"}, definition.ContainerRequest.Cmd)
+	})
+
+	t.Run("Should mount the server config file", func(t *testing.T) {
+		// Arrange
+		file := writeFile(t, "postgresql.conf", "listen_addresses = '*'\n")
+
+		// Act
+		definition := container.NewContainerDefinition(
+			postgres.WithPostgresContainer(postgres.WithServerConfigFile(file)),
+		)
+
+		// Assert
+		assert.Equal(t, []string{"postgres", "-c", "config_file=" + postgres.ConfigFilePath}, definition.ContainerRequest.Cmd)
+		assert.Len(t, definition.ContainerRequest.Files, 1)
+		assert.Equal(t, file, definition.ContainerRequest.Files[0].HostFilePath)
+		assert.Equal(t, postgres.ConfigFilePath, definition.ContainerRequest.Files[0].ContainerFilePath)
+	})
+
+	t.Run("Should combine the server config with the config file", func(t *testing.T) {
+		// Arrange
+		file := writeFile(t, "postgresql.conf", "listen_addresses = '*'\n")
+
+		// Act
+		definition := container.NewContainerDefinition(
+			postgres.WithPostgresContainer(
+				postgres.WithServerConfigFile(file),
+				postgres.WithServerConfig(map[string]string{"max_connections": "200"}),
+			),
+		)
+
+		// Assert
+		assert.Equal(t, []string{"postgres", "-c", "config_file=" + postgres.ConfigFilePath, "-c", "max_connections=200"}, definition.ContainerRequest.Cmd)
+	})
+
+	t.Run("Should keep the command of the image without server config", func(t *testing.T) {
+		// Act
+		definition := container.NewContainerDefinition(
+			postgres.WithPostgresContainer(),
+		)
+
+		// Assert
+		assert.Equal(t, "postgres:16", definition.ContainerRequest.Image)
+		assert.Empty(t, definition.ContainerRequest.Cmd)
+		assert.Empty(t, definition.ContainerRequest.LifecycleHooks)
+	})
+
+	variants := []struct {
+		name    string
+		variant postgres.PostgresOption
+		image   string
+	}{
+		{name: "PostGIS", variant: postgres.WithPostGIS(), image: postgres.PostGISImage},
+		{name: "pgvector", variant: postgres.WithPgVector(), image: postgres.PgVectorImage},
+		{name: "Timescale", variant: postgres.WithTimescale(), image: postgres.TimescaleImage},
+	}
+
+	for _, tc := range variants {
+		t.Run("Should use the "+tc.name+" image and register the extension hook", func(t *testing.T) {
+			// Act
+			definition := container.NewContainerDefinition(
+				postgres.WithPostgresContainer(tc.variant),
+			)
+
+			// Assert
+			assert.Equal(t, tc.image, definition.ContainerRequest.Image)
+			assert.Len(t, definition.ContainerRequest.LifecycleHooks, 1)
+			assert.Len(t, definition.ContainerRequest.LifecycleHooks[0].PostReadies, 1)
+		})
+	}
+
+	t.Run("Should apply the variant whatever its position among the options", func(t *testing.T) {
+		// Arrange
+		config := map[string]string{"max_connections": "200"}
+
+		// Act
+		first := container.NewContainerDefinition(
+			postgres.WithPostgresContainer(
+				postgres.WithPgVector(),
+				postgres.WithServerConfig(config),
+				postgres.WithDatabase("vectors"),
+			),
+			container.WithForceWaitDuration(time.Second),
+		)
+		last := container.NewContainerDefinition(
+			container.WithForceWaitDuration(time.Second),
+			postgres.WithPostgresContainer(
+				postgres.WithDatabase("vectors"),
+				postgres.WithServerConfig(config),
+				postgres.WithPgVector(),
+			),
+		)
+
+		// Assert
+		for _, definition := range []*container.Container{first, last} {
+			assert.Equal(t, postgres.PgVectorImage, definition.ContainerRequest.Image)
+			assert.Equal(t, []string{"postgres", "-c", "max_connections=200"}, definition.ContainerRequest.Cmd)
+			assert.Equal(t, "vectors", definition.ContainerRequest.Env["POSTGRES_DB"])
+			assert.Len(t, definition.ContainerRequest.LifecycleHooks, 1)
+		}
+	})
+
+	t.Run("Should apply the variant given to the module", func(t *testing.T) {
+		// Arrange
+		module := postgres.NewModule(postgres.WithTimescale())
+
+		// Act
+		definition := container.NewContainerDefinition(module.Definition()...)
+
+		// Assert
+		assert.Equal(t, postgres.TimescaleImage, definition.ContainerRequest.Image)
+		assert.Len(t, definition.ContainerRequest.LifecycleHooks, 1)
+	})
+}
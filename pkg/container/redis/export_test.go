@@ -0,0 +1,23 @@
+package redis
+
+import (
+	"context"
+	"net"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+)
+
+// NodeArgs exposes the redis-server arguments of the nodes to the tests
+func (t *Topology) NodeArgs(alias string) []string {
+	return t.nodeArgs(alias)
+}
+
+// WithSentinel exposes the sentinel definition to the tests
+func (t *Topology) WithSentinel(masterAlias string) container.ContainerOption {
+	return t.withSentinel(masterAlias)
+}
+
+// Dialer exposes the address translation of the clients to the tests
+func (t *Topology) Dialer(ctx context.Context) (func(ctx context.Context, network string, addr string) (net.Conn, error), error) {
+	return t.dialer(ctx)
+}
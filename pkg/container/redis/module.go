@@ -0,0 +1,85 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+var _ container.Module = (*Module)(nil)
+
+// Module is a type that represents the Redis module
+type Module struct {
+	opts []RedisOption
+}
+
+// NewModule returns the Redis module with the given options
+//
+// Example:
+//
+//	module := redis.NewModule(
+//		redis.WithPassword("secret"),
+//		redis.WithNetwork(ntwrkDefinition),
+//	)
+func NewModule(opts ...RedisOption) *Module {
+	return &Module{
+		opts: opts,
+	}
+}
+
+// Definition returns the options of the Redis container definition
+func (m *Module) Definition() []container.ContainerOption {
+	return []container.ContainerOption{
+		WithRedisContainer(m.opts...),
+	}
+}
+
+// Readiness returns the strategy used to wait for the Redis container to be ready
+func (m *Module) Readiness() wait.Strategy {
+	return ForPing(m.opts...).
+		WithStartupTimeout(30 * time.Second)
+}
+
+// InternalEndpoint returns the address of the container for the containers of the same network
+func (m *Module) InternalEndpoint(ctx context.Context, container testcontainers.Container) (string, error) {
+	return BuildInternalAddress(ctx, container, m.opts...)
+}
+
+// ExternalEndpoint returns the address of the container for the host
+func (m *Module) ExternalEndpoint(ctx context.Context, container testcontainers.Container) (string, error) {
+	return BuildExternalAddress(ctx, container, m.opts...)
+}
+
+// Reset deletes the keys of all the databases, see Flush
+func (m *Module) Reset(ctx context.Context, container testcontainers.Container) error {
+	connInfo, err := BuildExternalConnInfo(ctx, container, m.opts...)
+	if err != nil {
+		return err
+	}
+
+	client, err := connInfo.Client(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return Flush(ctx, client)
+}
+
+// EnvForDependents returns the connection information as REDIS_URL, REDIS_ADDR, REDIS_USERNAME and REDIS_PASSWORD
+func (m *Module) EnvForDependents(ctx context.Context, container testcontainers.Container) (map[string]string, error) {
+	connInfo, err := BuildInternalConnInfo(ctx, container, m.opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"REDIS_URL":      connInfo.URL(),
+		"REDIS_ADDR":     connInfo.Addr(),
+		"REDIS_USERNAME": connInfo.Username,
+		"REDIS_PASSWORD": connInfo.Password,
+	}, nil
+}
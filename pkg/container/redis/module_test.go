@@ -0,0 +1,48 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/jfelipearaujo/testcontainers/pkg/container/redis"
+	"github.com/jfelipearaujo/testcontainers/pkg/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModule(t *testing.T) {
+	t.Run("Should honor the options in the definition", func(t *testing.T) {
+		// Arrange
+		module := redis.NewModule(
+			redis.WithImage("redis:6"),
+			redis.WithPassword("secret"),
+		)
+
+		// Act
+		definition := container.NewModuleDefinition(module)
+
+		// Assert
+		assert.Equal(t, "redis:6", definition.ContainerRequest.Image)
+		assert.Contains(t, definition.ContainerRequest.Cmd, "--requirepass")
+		assert.NotNil(t, definition.ContainerRequest.WaitingFor)
+	})
+
+	t.Run("Should return the environment of the dependents", func(t *testing.T) {
+		// Arrange
+		module := redis.NewModule(
+			redis.WithPassword("secret"),
+			redis.WithDatabase(1),
+			redis.WithNetwork(network.NewNetwork(network.WithAlias("cache"))),
+		)
+
+		// Act
+		env, err := module.EnvForDependents(context.Background(), nil)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "redis://:secret@cache:6379/1", env["REDIS_URL"])
+		assert.Equal(t, "cache:6379", env["REDIS_ADDR"])
+		assert.Equal(t, "secret", env["REDIS_PASSWORD"])
+	})
+}
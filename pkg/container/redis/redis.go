@@ -0,0 +1,236 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/jfelipearaujo/testcontainers/pkg/network"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+const (
+	Image       string = "redis:7"
+	ExposedPort string = "6379"
+)
+
+// DefaultACLRules are the rules of the ACL users created without rules: all the keys, channels and commands
+var DefaultACLRules = []string{"~*", "&*", "+@all"}
+
+// ACLUser is a type that represents an ACL user of the Redis container
+type ACLUser struct {
+	Name     string
+	Password string
+	Rules    []string
+}
+
+// Options is a type that represents the options for a Redis container
+//
+//	Default options:
+//		Image: "redis:7"
+//		ExposedPort: "6379"
+//		Password: ""
+//		Users: nil
+//		User: "" (the default user)
+//		Persistence: false
+//		Database: 0
+//
+//	Default network alias: nil
+type Options struct {
+	Image        string
+	ExposedPort  string
+	Password     string
+	Users        []ACLUser
+	User         string
+	Persistence  bool
+	Database     int
+	NetworkAlias *string
+}
+
+// RedisOption is a type that represents a Redis option
+type RedisOption func(*Options)
+
+// WithImage is a RedisOption that sets the image of the Redis container
+//
+//	Default: "redis:7"
+func WithImage(image string) RedisOption {
+	return func(options *Options) {
+		options.Image = image
+	}
+}
+
+// WithExposedPort is a RedisOption that sets the exposed port of the Redis container
+//
+//	Default: "6379"
+func WithExposedPort(exposedPort string) RedisOption {
+	return func(options *Options) {
+		options.ExposedPort = exposedPort
+	}
+}
+
+// WithPassword is a RedisOption that sets the password of the default user of the Redis container
+//
+//	Default: ""
+func WithPassword(password string) RedisOption {
+	return func(options *Options) {
+		options.Password = password
+	}
+}
+
+// WithACLUsers is a RedisOption that creates the given ACL users, with DefaultACLRules when no rule is set
+//
+//	Default: nil
+//
+// Example:
+//
+//	redis.WithACLUsers(redis.ACLUser{
+//		Name:     "reader",
+//		Password: "secret",
+//		Rules:    []string{"~cache:*", "+@read"},
+//	})
+func WithACLUsers(users ...ACLUser) RedisOption {
+	return func(options *Options) {
+		options.Users = append(options.Users, users...)
+	}
+}
+
+// WithUser is a RedisOption that sets the user of the connection, the password being the one of the ACL user
+// with the same name, or the password of the default user when there is none
+//
+//	Default: "" (the default user)
+//
+// Example:
+//
+//	connInfo, err := redis.BuildExternalConnInfo(ctx, redisContainer,
+//		redis.WithACLUsers(redis.ACLUser{Name: "reader", Password: "secret"}),
+//		redis.WithUser("reader"),
+//	)
+func WithUser(user string) RedisOption {
+	return func(options *Options) {
+		options.User = user
+	}
+}
+
+// WithPersistence is a RedisOption that enables the append-only file, the RDB snapshots being always disabled
+//
+//	Default: false
+func WithPersistence(persistence bool) RedisOption {
+	return func(options *Options) {
+		options.Persistence = persistence
+	}
+}
+
+// WithDatabase is a RedisOption that sets the database number of the connection
+//
+//	Default: 0
+func WithDatabase(database int) RedisOption {
+	return func(options *Options) {
+		options.Database = database
+	}
+}
+
+// WithNetwork is a RedisOption that sets the network alias of the Redis container
+//
+//	Default: nil
+func WithNetwork(network *network.Network) RedisOption {
+	return func(options *Options) {
+		options.NetworkAlias = &network.Alias
+	}
+}
+
+// Return a Redis address for the given container with default options when the container is in a network
+//
+//	Example: "network_alias:6379"
+func BuildInternalAddress(ctx context.Context, container testcontainers.Container, opts ...RedisOption) (string, error) {
+	connInfo, err := BuildInternalConnInfo(ctx, container, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	return connInfo.Addr(), nil
+}
+
+// Return a Redis address for the given container with default options when the container is NOT in a network
+//
+//	Example: "localhost:49153"
+func BuildExternalAddress(ctx context.Context, container testcontainers.Container, opts ...RedisOption) (string, error) {
+	connInfo, err := BuildExternalConnInfo(ctx, container, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	return connInfo.Addr(), nil
+}
+
+func buildOptions(opts ...RedisOption) *Options {
+	options := &Options{
+		Image:       Image,
+		ExposedPort: ExposedPort,
+	}
+
+	for _, o := range opts {
+		o(options)
+	}
+
+	return options
+}
+
+// serverArgs returns the redis-server arguments of the given options
+func serverArgs(options *Options) []string {
+	args := []string{"--save", ""}
+
+	if options.Persistence {
+		args = append(args, "--appendonly", "yes")
+	} else {
+		args = append(args, "--appendonly", "no")
+	}
+
+	if options.Password != "" {
+		args = append(args, "--requirepass", options.Password)
+	}
+
+	for _, user := range options.Users {
+		rules := user.Rules
+		if len(rules) == 0 {
+			rules = DefaultACLRules
+		}
+
+		args = append(args, "--user", user.Name, "on", ">"+user.Password)
+		args = append(args, rules...)
+	}
+
+	return args
+}
+
+// Return a new container definition for a Redis container with default options
+//
+//	DockerImage: "redis:7"
+//	Exposed ports: "6379"
+//	Command: redis-server --save "" --appendonly no
+//
+//	WaitingForPing: "PING" command
+//	StartupTimeout: "30 seconds"
+//
+// The same RedisOptions must be given to the address builders, so the credentials match the container.
+//
+// Example:
+//
+//	definition := container.NewContainerDefinition(
+//		redis.WithRedisContainer(
+//			redis.WithPassword("secret"),
+//			redis.WithPersistence(true),
+//		),
+//	)
+func WithRedisContainer(opts ...RedisOption) container.ContainerOption {
+	options := buildOptions(opts...)
+
+	return func(container *container.Container) {
+		container.ContainerRequest.Image = options.Image
+		container.ContainerRequest.ExposedPorts = []string{
+			options.ExposedPort,
+		}
+		container.ContainerRequest.Cmd = append([]string{"redis-server"}, serverArgs(options)...)
+		container.ContainerRequest.WaitingFor = ForPing(opts...).
+			WithStartupTimeout(30 * time.Second)
+	}
+}
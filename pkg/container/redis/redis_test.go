@@ -0,0 +1,96 @@
+package redis_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/jfelipearaujo/testcontainers/pkg/container/redis"
+	"github.com/jfelipearaujo/testcontainers/pkg/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRedisContainer(t *testing.T) {
+	t.Run("Should use the default options", func(t *testing.T) {
+		// Act
+		definition := container.NewContainerDefinition(
+			redis.WithRedisContainer(),
+		)
+
+		// Assert
+		assert.Equal(t, redis.Image, definition.ContainerRequest.Image)
+		assert.Equal(t, []string{redis.ExposedPort}, definition.ContainerRequest.ExposedPorts)
+		assert.Equal(t, []string{"redis-server", "--save", "", "--appendonly", "no"}, definition.ContainerRequest.Cmd)
+		assert.NotNil(t, definition.ContainerRequest.WaitingFor)
+	})
+
+	t.Run("Should honor the options", func(t *testing.T) {
+		// Act
+		definition := container.NewContainerDefinition(
+			redis.WithRedisContainer(
+				redis.WithImage("redis:6"),
+				redis.WithExposedPort("6380"),
+				redis.WithPassword("secret"),
+				redis.WithPersistence(true),
+			),
+		)
+
+		// Assert
+		assert.Equal(t, "redis:6", definition.ContainerRequest.Image)
+		assert.Equal(t, []string{"6380"}, definition.ContainerRequest.ExposedPorts)
+		assert.Equal(t, []string{
+			"redis-server",
+			"--save", "",
+			"--appendonly", "yes",
+			"--requirepass", "secret",
+		}, definition.ContainerRequest.Cmd)
+	})
+
+	t.Run("Should create the ACL users", func(t *testing.T) {
+		// Act
+		definition := container.NewContainerDefinition(
+			redis.WithRedisContainer(
+				redis.WithACLUsers(
+					redis.ACLUser{Name: "app", Password: "secret"},
+					redis.ACLUser{Name: "reader", Password: "read", Rules: []string{"~cache:*", "+@read"}},
+				),
+			),
+		)
+
+		// Assert
+		assert.Equal(t, []string{
+			"redis-server",
+			"--save", "",
+			"--appendonly", "no",
+			"--user", "app", "on", ">secret", "~*", "&*", "+@all",
+			"--user", "reader", "on", ">read", "~cache:*", "+@read",
+		}, definition.ContainerRequest.Cmd)
+	})
+}
+
+func TestBuildInternalAddress(t *testing.T) {
+	t.Run("Should return the address of the network alias", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+		ntwrkDefinition := network.NewNetwork(network.WithAlias("cache"))
+
+		// Act
+		res, err := redis.BuildInternalAddress(ctx, nil, redis.WithNetwork(ntwrkDefinition))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "cache:6379", res)
+	})
+
+	t.Run("Should return an error when the container is not in a network", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		// Act
+		_, err := redis.BuildInternalAddress(ctx, nil)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
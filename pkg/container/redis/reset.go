@@ -0,0 +1,46 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Flush deletes the keys of all the databases, on every master of a cluster
+//
+// Example:
+//
+//	err := redis.Flush(ctx, client)
+func Flush(ctx context.Context, client goredis.UniversalClient) error {
+	if cluster, ok := client.(*goredis.ClusterClient); ok {
+		err := cluster.ForEachMaster(ctx, func(ctx context.Context, master *goredis.Client) error {
+			return master.FlushAll(ctx).Err()
+		})
+		if err != nil {
+			return fmt.Errorf("failed to flush the cluster: %w", err)
+		}
+		return nil
+	}
+
+	if err := client.FlushAll(ctx).Err(); err != nil {
+		return fmt.Errorf("failed to flush the server: %w", err)
+	}
+
+	return nil
+}
+
+// ResetHook returns a reset hook that calls Flush, to be registered on a shared group of containers
+//
+// Example:
+//
+//	group := container.BuildGroupContainer(
+//		container.WithDockerContainer(redisContainer),
+//		container.WithResetHook(redis.ResetHook(client)),
+//	)
+func ResetHook(client goredis.UniversalClient) container.ResetFunc {
+	return func(ctx context.Context) error {
+		return Flush(ctx, client)
+	}
+}
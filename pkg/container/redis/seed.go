@@ -0,0 +1,165 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"gopkg.in/yaml.v3"
+)
+
+// KeyType is a type that represents the type of a seeded key
+type KeyType string
+
+const (
+	String KeyType = "string"
+	Hash   KeyType = "hash"
+	List   KeyType = "list"
+	Set    KeyType = "set"
+)
+
+// Key is a type that represents a key to be seeded
+//
+// Value is a string for the String keys, a map[string]string for the Hash keys and a []string for the List
+// and Set keys. TTL is not set when zero.
+type Key struct {
+	Name  string
+	Type  KeyType
+	Value any
+	TTL   time.Duration
+}
+
+// ReadKeys reads the keys of a YAML file, the type defaulting to "string"
+//
+// Example:
+//
+//	# keys.yaml
+//	- name: session:1
+//	  value: '{"userId": 1}'
+//	  ttl: 30m
+//	- name: user:1
+//	  type: hash
+//	  value:
+//	    name: John
+//	- name: queue:jobs
+//	  type: list
+//	  value: [job-1, job-2]
+func ReadKeys(file string) ([]Key, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the keys '%s': %w", file, err)
+	}
+
+	var values []struct {
+		Name  string    `yaml:"name"`
+		Type  KeyType   `yaml:"type"`
+		Value yaml.Node `yaml:"value"`
+		TTL   string    `yaml:"ttl"`
+	}
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse the keys '%s': %w", file, err)
+	}
+
+	keys := make([]Key, len(values))
+	for i, value := range values {
+		key := Key{
+			Name: value.Name,
+			Type: value.Type,
+		}
+		if key.Type == "" {
+			key.Type = String
+		}
+
+		if value.TTL != "" {
+			key.TTL, err = time.ParseDuration(value.TTL)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ttl of the key '%s': %w", key.Name, err)
+			}
+		}
+
+		switch key.Type {
+		case String:
+			var v string
+			err = value.Value.Decode(&v)
+			key.Value = v
+		case Hash:
+			var v map[string]string
+			err = value.Value.Decode(&v)
+			key.Value = v
+		case List, Set:
+			var v []string
+			err = value.Value.Decode(&v)
+			key.Value = v
+		default:
+			err = fmt.Errorf("unknown type '%s'", key.Type)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of the key '%s': %w", key.Name, err)
+		}
+
+		keys[i] = key
+	}
+
+	return keys, nil
+}
+
+// SeedKeys writes the given keys, replacing the existing ones
+//
+// Example:
+//
+//	err := redis.SeedKeys(ctx, client,
+//		redis.Key{Name: "feature:checkout", Value: "on"},
+//		redis.Key{Name: "user:1", Type: redis.Hash, Value: map[string]string{"name": "John"}, TTL: time.Hour},
+//	)
+func SeedKeys(ctx context.Context, client goredis.UniversalClient, keys ...Key) error {
+	for _, key := range keys {
+		if err := client.Del(ctx, key.Name).Err(); err != nil {
+			return fmt.Errorf("failed to delete the key '%s': %w", key.Name, err)
+		}
+
+		var err error
+		switch value := key.Value.(type) {
+		case string:
+			err = client.Set(ctx, key.Name, value, key.TTL).Err()
+		case map[string]string:
+			err = client.HSet(ctx, key.Name, value).Err()
+		case []string:
+			members := make([]any, len(value))
+			for i, member := range value {
+				members[i] = member
+			}
+
+			if key.Type == Set {
+				err = client.SAdd(ctx, key.Name, members...).Err()
+			} else {
+				err = client.RPush(ctx, key.Name, members...).Err()
+			}
+		default:
+			err = fmt.Errorf("unsupported value %T", key.Value)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to seed the key '%s': %w", key.Name, err)
+		}
+
+		// the ttl of the strings is set by SET, the other types need EXPIRE
+		if _, isString := key.Value.(string); key.TTL > 0 && !isString {
+			if err := client.Expire(ctx, key.Name, key.TTL).Err(); err != nil {
+				return fmt.Errorf("failed to set the ttl of the key '%s': %w", key.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SeedFile writes the keys of the given YAML file, see ReadKeys
+func SeedFile(ctx context.Context, client goredis.UniversalClient, file string) error {
+	keys, err := ReadKeys(file)
+	if err != nil {
+		return err
+	}
+
+	return SeedKeys(ctx, client, keys...)
+}
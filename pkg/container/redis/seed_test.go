@@ -0,0 +1,84 @@
+package redis_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name string, content string) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+
+	return file
+}
+
+func TestReadKeys(t *testing.T) {
+	t.Run("Should read the keys of every type", func(t *testing.T) {
+		// Arrange
+		file := writeFile(t, "keys.yaml", `
+- name: session:1
+  value: '{"userId": 1}'
+  ttl: 30m
+- name: user:1
+  type: hash
+  value:
+    name: John
+- name: queue:jobs
+  type: list
+  value: [job-1, job-2]
+- name: tags
+  type: set
+  value: [a, b]
+`)
+
+		// Act
+		keys, err := redis.ReadKeys(file)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []redis.Key{
+			{Name: "session:1", Type: redis.String, Value: `{"userId": 1}`, TTL: 30 * time.Minute},
+			{Name: "user:1", Type: redis.Hash, Value: map[string]string{"name": "John"}},
+			{Name: "queue:jobs", Type: redis.List, Value: []string{"job-1", "job-2"}},
+			{Name: "tags", Type: redis.Set, Value: []string{"a", "b"}},
+		}, keys)
+	})
+
+	t.Run("Should return an error for an unknown type", func(t *testing.T) {
+		// Arrange
+		file := writeFile(t, "keys.yaml", `
+- name: events
+  type: stream
+  value: []
+`)
+
+		// Act
+		_, err := redis.ReadKeys(file)
+
+		// Assert
+		assert.Error(t, err)
+	})
+
+	t.Run("Should return an error for an invalid ttl", func(t *testing.T) {
+		// Arrange
+		file := writeFile(t, "keys.yaml", `
+- name: session:1
+  value: token
+  ttl: soon
+`)
+
+		// Act
+		_, err := redis.ReadKeys(file)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
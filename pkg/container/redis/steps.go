@@ -0,0 +1,247 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cucumber/godog"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Steps is a type that represents the key steps of a Redis container
+type Steps struct {
+	Client func(ctx context.Context) (goredis.UniversalClient, error)
+}
+
+// RegisterSteps registers the key steps on the given scenario context
+//
+// The client function must return the client of the current scenario. The registered steps are:
+//
+//	Given the keys in "./testdata/keys.yaml" are seeded
+//	Then the key "session:1" should exist
+//	Then the key "session:1" should not exist
+//	Then the key "feature:checkout" should have the value "on"
+//	Then the key "session:1" should expire within "30m"
+//	Then the key "user:1" should not expire
+//	Then the stream "events" should contain 2 entries
+//	Then the stream "events" should contain an entry with:
+//
+// The stream entry fields are given as a "| field | value |" table and are matched as a subset of the entries
+func RegisterSteps(ctx *godog.ScenarioContext, client func(ctx context.Context) (goredis.UniversalClient, error)) *Steps {
+	steps := &Steps{
+		Client: client,
+	}
+
+	ctx.Step(`^the keys in "([^"]*)" are seeded$`, steps.theKeysAreSeeded)
+	ctx.Step(`^the key "([^"]*)" should exist$`, steps.theKeyShouldExist)
+	ctx.Step(`^the key "([^"]*)" should not exist$`, steps.theKeyShouldNotExist)
+	ctx.Step(`^the key "([^"]*)" should have the value "([^"]*)"$`, steps.theKeyShouldHaveTheValue)
+	ctx.Step(`^the key "([^"]*)" should expire within "([^"]*)"$`, steps.theKeyShouldExpireWithin)
+	ctx.Step(`^the key "([^"]*)" should not expire$`, steps.theKeyShouldNotExpire)
+	ctx.Step(`^the stream "([^"]*)" should contain (\d+) entr(?:y|ies)$`, steps.theStreamShouldContainEntries)
+	ctx.Step(`^the stream "([^"]*)" should contain an entry with:$`, steps.theStreamShouldContainAnEntryWith)
+
+	return steps
+}
+
+func (steps *Steps) theKeysAreSeeded(ctx context.Context, file string) (context.Context, error) {
+	client, err := steps.Client(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	return ctx, SeedFile(ctx, client, file)
+}
+
+func (steps *Steps) theKeyShouldExist(ctx context.Context, key string) (context.Context, error) {
+	exists, err := steps.exists(ctx, key)
+	if err != nil {
+		return ctx, err
+	}
+
+	if !exists {
+		return ctx, fmt.Errorf("expected the key '%s' to exist, but it does not", key)
+	}
+
+	return ctx, nil
+}
+
+func (steps *Steps) theKeyShouldNotExist(ctx context.Context, key string) (context.Context, error) {
+	exists, err := steps.exists(ctx, key)
+	if err != nil {
+		return ctx, err
+	}
+
+	if exists {
+		return ctx, fmt.Errorf("expected the key '%s' not to exist, but it does", key)
+	}
+
+	return ctx, nil
+}
+
+func (steps *Steps) theKeyShouldHaveTheValue(ctx context.Context, key string, expected string) (context.Context, error) {
+	client, err := steps.Client(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	value, err := client.Get(ctx, key).Result()
+	if err == goredis.Nil {
+		return ctx, fmt.Errorf("expected the key '%s' to have the value '%s', but it does not exist", key, expected)
+	}
+	if err != nil {
+		return ctx, fmt.Errorf("failed to get the key '%s': %w", key, err)
+	}
+
+	if value != expected {
+		return ctx, fmt.Errorf("expected the key '%s' to have the value '%s', but got '%s'", key, expected, value)
+	}
+
+	return ctx, nil
+}
+
+func (steps *Steps) theKeyShouldExpireWithin(ctx context.Context, key string, within string) (context.Context, error) {
+	max, err := time.ParseDuration(within)
+	if err != nil {
+		return ctx, fmt.Errorf("invalid duration '%s': %w", within, err)
+	}
+
+	ttl, err := steps.ttl(ctx, key)
+	if err != nil {
+		return ctx, err
+	}
+
+	if ttl < 0 || ttl > max {
+		return ctx, fmt.Errorf("expected the key '%s' to expire within %s, but its ttl is %s", key, max, formatTTL(ttl))
+	}
+
+	return ctx, nil
+}
+
+func (steps *Steps) theKeyShouldNotExpire(ctx context.Context, key string) (context.Context, error) {
+	ttl, err := steps.ttl(ctx, key)
+	if err != nil {
+		return ctx, err
+	}
+
+	if ttl != -1 {
+		return ctx, fmt.Errorf("expected the key '%s' not to expire, but its ttl is %s", key, formatTTL(ttl))
+	}
+
+	return ctx, nil
+}
+
+func (steps *Steps) theStreamShouldContainEntries(ctx context.Context, stream string, expected int64) (context.Context, error) {
+	client, err := steps.Client(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	count, err := client.XLen(ctx, stream).Result()
+	if err != nil {
+		return ctx, fmt.Errorf("failed to count the entries of the stream '%s': %w", stream, err)
+	}
+
+	if count != expected {
+		return ctx, fmt.Errorf("expected %d entries in the stream '%s', but got %d", expected, stream, count)
+	}
+
+	return ctx, nil
+}
+
+func (steps *Steps) theStreamShouldContainAnEntryWith(ctx context.Context, stream string, table *godog.Table) (context.Context, error) {
+	expected := make(map[string]string, len(table.Rows))
+	for _, row := range table.Rows {
+		if len(row.Cells) != 2 {
+			return ctx, fmt.Errorf("the fields table must have 2 columns: field and value")
+		}
+		expected[row.Cells[0].Value] = row.Cells[1].Value
+	}
+
+	client, err := steps.Client(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	entries, err := client.XRange(ctx, stream, "-", "+").Result()
+	if err != nil {
+		return ctx, fmt.Errorf("failed to read the stream '%s': %w", stream, err)
+	}
+
+	for _, entry := range entries {
+		if matchFields(expected, entry.Values) {
+			return ctx, nil
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "no entry of the stream '%s' matches (%d entries)", stream, len(entries))
+	for _, entry := range entries {
+		fmt.Fprintf(&sb, "\n  %s: %s", entry.ID, formatFields(entry.Values))
+	}
+
+	return ctx, errors.New(sb.String())
+}
+
+func (steps *Steps) exists(ctx context.Context, key string) (bool, error) {
+	client, err := steps.Client(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	count, err := client.Exists(ctx, key).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check the key '%s': %w", key, err)
+	}
+
+	return count > 0, nil
+}
+
+// ttl returns the ttl of the key, -1 when the key does not expire and -2 when the key does not exist
+func (steps *Steps) ttl(ctx context.Context, key string) (time.Duration, error) {
+	client, err := steps.Client(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// go-redis returns the negative replies as is, without the millisecond unit
+	ttl, err := client.PTTL(ctx, key).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the ttl of the key '%s': %w", key, err)
+	}
+
+	return ttl, nil
+}
+
+func formatTTL(ttl time.Duration) string {
+	switch ttl {
+	case -1:
+		return "none"
+	case -2:
+		return "none (the key does not exist)"
+	default:
+		return ttl.String()
+	}
+}
+
+func matchFields(expected map[string]string, actual map[string]any) bool {
+	for field, value := range expected {
+		if v, ok := actual[field]; !ok || fmt.Sprint(v) != value {
+			return false
+		}
+	}
+	return true
+}
+
+func formatFields(values map[string]any) string {
+	fields := make([]string, 0, len(values))
+	for field, value := range values {
+		fields = append(fields, fmt.Sprintf("%s=%v", field, value))
+	}
+	sort.Strings(fields)
+	return strings.Join(fields, ", ")
+}
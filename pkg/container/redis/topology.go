@@ -0,0 +1,548 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/jfelipearaujo/testcontainers/pkg/network"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/testcontainers/testcontainers-go"
+	tcexec "github.com/testcontainers/testcontainers-go/exec"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	SentinelPort      string = "26379"
+	DefaultMasterName string = "mymaster"
+)
+
+// TopologyMode is a type that represents the mode of a Redis topology
+type TopologyMode string
+
+const (
+	// SentinelMode is a master, N replicas and a sentinel monitoring the master
+	SentinelMode TopologyMode = "sentinel"
+	// ClusterMode is N masters sharing the hash slots, each one with its own replicas
+	ClusterMode TopologyMode = "cluster"
+)
+
+// TopologyOptions is a type that represents the options of a Redis topology
+//
+//	Default options:
+//		Mode: "sentinel"
+//		Image: "redis:7"
+//		Masters: 3 (cluster mode only)
+//		Replicas: 1 (per master in cluster mode)
+//		AliasPrefix: "redis"
+//		MasterName: "mymaster" (sentinel mode only)
+//		Password: ""
+//		StartupTimeout: "60 seconds"
+type TopologyOptions struct {
+	Mode           TopologyMode
+	Image          string
+	Masters        int
+	Replicas       int
+	AliasPrefix    string
+	MasterName     string
+	Password       string
+	StartupTimeout time.Duration
+}
+
+// TopologyOption is a type that represents a Redis topology option
+type TopologyOption func(*TopologyOptions)
+
+// WithMode is a TopologyOption that sets the mode of the topology
+//
+//	Default: "sentinel"
+func WithMode(mode TopologyMode) TopologyOption {
+	return func(options *TopologyOptions) {
+		options.Mode = mode
+	}
+}
+
+// WithTopologyImage is a TopologyOption that sets the image of the nodes, the cluster mode requiring Redis 7 or later
+//
+//	Default: "redis:7"
+func WithTopologyImage(image string) TopologyOption {
+	return func(options *TopologyOptions) {
+		options.Image = image
+	}
+}
+
+// WithMasters is a TopologyOption that sets the number of masters of the cluster mode, at least 3
+//
+//	Default: 3
+func WithMasters(masters int) TopologyOption {
+	return func(options *TopologyOptions) {
+		options.Masters = masters
+	}
+}
+
+// WithReplicas is a TopologyOption that sets the number of replicas, per master in cluster mode
+//
+//	Default: 1
+func WithReplicas(replicas int) TopologyOption {
+	return func(options *TopologyOptions) {
+		options.Replicas = replicas
+	}
+}
+
+// WithAliasPrefix is a TopologyOption that sets the prefix of the network aliases of the nodes
+//
+//	Default: "redis" (redis-master, redis-replica-1, redis-sentinel or redis-node-1, redis-node-2, ...)
+func WithAliasPrefix(prefix string) TopologyOption {
+	return func(options *TopologyOptions) {
+		options.AliasPrefix = prefix
+	}
+}
+
+// WithMasterName is a TopologyOption that sets the name of the master monitored by the sentinel
+//
+//	Default: "mymaster"
+func WithMasterName(name string) TopologyOption {
+	return func(options *TopologyOptions) {
+		options.MasterName = name
+	}
+}
+
+// WithTopologyPassword is a TopologyOption that sets the password of the nodes, the sentinel being started without one
+//
+//	Default: ""
+func WithTopologyPassword(password string) TopologyOption {
+	return func(options *TopologyOptions) {
+		options.Password = password
+	}
+}
+
+// WithTopologyStartupTimeout is a TopologyOption that sets the startup timeout of each node
+//
+//	Default: 60 seconds
+func WithTopologyStartupTimeout(timeout time.Duration) TopologyOption {
+	return func(options *TopologyOptions) {
+		options.StartupTimeout = timeout
+	}
+}
+
+// Node is a type that represents a node of a Redis topology
+type Node struct {
+	Alias     string
+	Port      string
+	Container testcontainers.Container
+}
+
+// InternalAddr returns the address of the node for containers in the same network
+func (node *Node) InternalAddr() string {
+	return net.JoinHostPort(node.Alias, node.Port)
+}
+
+// ExternalAddr returns the address of the node for clients running on the host
+func (node *Node) ExternalAddr(ctx context.Context) (string, error) {
+	host, err := node.Container.Host(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the host of %s: %w", node.Alias, err)
+	}
+
+	port, err := node.Container.MappedPort(ctx, nat.Port(node.Port))
+	if err != nil {
+		return "", fmt.Errorf("failed to get the mapped port of %s: %w", node.Alias, err)
+	}
+
+	return net.JoinHostPort(host, port.Port()), nil
+}
+
+// Topology is a type that represents a Sentinel or a Cluster topology sharing a network
+//
+// In sentinel mode the Nodes are the master followed by its replicas, in cluster mode the Nodes are the masters
+// followed by the replicas, and Sentinel is nil.
+type Topology struct {
+	Nodes    []*Node
+	Sentinel *Node
+
+	network *network.Network
+	options *TopologyOptions
+}
+
+// NewTopology creates a new Redis topology definition that will be started on the given network
+//
+// Example:
+//
+//	topology := redis.NewTopology(ntwrkDefinition,
+//		redis.WithMode(redis.ClusterMode),
+//		redis.WithReplicas(0),
+//	)
+//	if err := topology.Build(ctx); err != nil {
+//		return ctx, err
+//	}
+//
+//	client, err := topology.Client(ctx)
+func NewTopology(ntw *network.Network, opts ...TopologyOption) *Topology {
+	options := &TopologyOptions{
+		Mode:           SentinelMode,
+		Image:          Image,
+		Masters:        3,
+		Replicas:       1,
+		AliasPrefix:    "redis",
+		MasterName:     DefaultMasterName,
+		StartupTimeout: 60 * time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return &Topology{
+		network: ntw,
+		options: options,
+	}
+}
+
+// Build starts the nodes of the topology and waits for the topology to be ready
+//
+// When the topology fails to start, the nodes already started are terminated.
+func (t *Topology) Build(ctx context.Context) error {
+	var err error
+	switch t.options.Mode {
+	case SentinelMode:
+		err = t.buildSentinel(ctx)
+	case ClusterMode:
+		err = t.buildCluster(ctx)
+	default:
+		err = fmt.Errorf("unknown topology mode '%s'", t.options.Mode)
+	}
+
+	if err != nil {
+		return errors.Join(err, t.terminate(context.WithoutCancel(ctx)))
+	}
+
+	return nil
+}
+
+// terminate terminates the nodes already started and forgets them
+func (t *Topology) terminate(ctx context.Context) error {
+	var errs []error
+	for _, node := range t.Containers() {
+		if err := node.Terminate(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	t.Nodes = nil
+	t.Sentinel = nil
+
+	return errors.Join(errs...)
+}
+
+// Containers returns the containers of all the nodes, to be destroyed with the group
+func (t *Topology) Containers() []testcontainers.Container {
+	var containers []testcontainers.Container
+
+	for _, node := range t.Nodes {
+		containers = append(containers, node.Container)
+	}
+
+	if t.Sentinel != nil {
+		containers = append(containers, t.Sentinel.Container)
+	}
+
+	return containers
+}
+
+// MasterName returns the name of the master monitored by the sentinel
+func (t *Topology) MasterName() string {
+	return t.options.MasterName
+}
+
+// InternalAddrs returns the addresses the containers in the same network use to connect: the sentinel address
+// in sentinel mode, the addresses of all the nodes in cluster mode
+func (t *Topology) InternalAddrs() []string {
+	if t.Sentinel != nil {
+		return []string{t.Sentinel.InternalAddr()}
+	}
+
+	addrs := make([]string, len(t.Nodes))
+	for i, node := range t.Nodes {
+		addrs[i] = node.InternalAddr()
+	}
+
+	return addrs
+}
+
+// Client returns a new client for the host, a failover client in sentinel mode and a cluster client in cluster mode
+//
+// The nodes announce their network aliases, so the client translates them into the mapped ports of the host.
+func (t *Topology) Client(ctx context.Context) (goredis.UniversalClient, error) {
+	dialer, err := t.dialer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var client goredis.UniversalClient
+	if t.Sentinel != nil {
+		client = goredis.NewFailoverClient(&goredis.FailoverOptions{
+			MasterName:    t.options.MasterName,
+			SentinelAddrs: []string{t.Sentinel.InternalAddr()},
+			Password:      t.options.Password,
+			Dialer:        dialer,
+		})
+	} else {
+		client = goredis.NewClusterClient(&goredis.ClusterOptions{
+			Addrs:    t.InternalAddrs(),
+			Password: t.options.Password,
+			Dialer:   dialer,
+		})
+	}
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to ping the topology: %w", err)
+	}
+
+	return client, nil
+}
+
+// dialer returns a dialer that translates the internal addresses, by alias or by IP, into the addresses of the host
+func (t *Topology) dialer(ctx context.Context) (func(ctx context.Context, network string, addr string) (net.Conn, error), error) {
+	nodes := t.Nodes
+	if t.Sentinel != nil {
+		nodes = append(nodes[:len(nodes):len(nodes)], t.Sentinel)
+	}
+
+	addrs := make(map[string]string, 2*len(nodes))
+	for _, node := range nodes {
+		external, err := node.ExternalAddr(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		ip, err := node.Container.ContainerIP(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the IP of %s: %w", node.Alias, err)
+		}
+
+		addrs[node.InternalAddr()] = external
+		addrs[net.JoinHostPort(ip, node.Port)] = external
+	}
+
+	return func(ctx context.Context, network string, addr string) (net.Conn, error) {
+		if external, ok := addrs[addr]; ok {
+			addr = external
+		}
+
+		var d net.Dialer
+		return d.DialContext(ctx, network, addr)
+	}, nil
+}
+
+func (t *Topology) buildSentinel(ctx context.Context) error {
+	dockerNetwork, err := t.network.Build(ctx)
+	if err != nil {
+		return err
+	}
+
+	masterAlias := t.options.AliasPrefix + "-master"
+
+	master, err := t.startNode(ctx, dockerNetwork, masterAlias, t.nodeArgs(masterAlias))
+	if err != nil {
+		return fmt.Errorf("failed to start the master: %w", err)
+	}
+	t.Nodes = append(t.Nodes, master)
+
+	for i := 1; i <= t.options.Replicas; i++ {
+		alias := fmt.Sprintf("%s-replica-%d", t.options.AliasPrefix, i)
+
+		args := append(t.nodeArgs(alias), "--replicaof", masterAlias, ExposedPort)
+
+		replica, err := t.startNode(ctx, dockerNetwork, alias, args)
+		if err != nil {
+			return fmt.Errorf("failed to start the replica %d: %w", i, err)
+		}
+		t.Nodes = append(t.Nodes, replica)
+	}
+
+	sentinelAlias := t.options.AliasPrefix + "-sentinel"
+
+	sentinel, err := container.NewContainerDefinition(
+		container.WithNetwork(sentinelAlias, dockerNetwork),
+		t.withSentinel(masterAlias),
+	).BuildContainer(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start the sentinel: %w", err)
+	}
+
+	t.Sentinel = &Node{
+		Alias:     sentinelAlias,
+		Port:      SentinelPort,
+		Container: sentinel,
+	}
+
+	return t.waitFor(ctx, master, "INFO replication", fmt.Sprintf("connected_slaves:%d", t.options.Replicas))
+}
+
+func (t *Topology) buildCluster(ctx context.Context) error {
+	if t.options.Masters < 3 {
+		return fmt.Errorf("a cluster needs at least 3 masters, got %d", t.options.Masters)
+	}
+
+	dockerNetwork, err := t.network.Build(ctx)
+	if err != nil {
+		return err
+	}
+
+	size := t.options.Masters * (1 + t.options.Replicas)
+
+	ips := make([]string, size)
+	for i := 1; i <= size; i++ {
+		alias := fmt.Sprintf("%s-node-%d", t.options.AliasPrefix, i)
+
+		args := append(t.nodeArgs(alias),
+			"--cluster-enabled", "yes",
+			"--cluster-config-file", "nodes.conf",
+			"--cluster-node-timeout", "5000",
+			"--cluster-announce-hostname", alias,
+			"--cluster-preferred-endpoint-type", "hostname")
+
+		node, err := t.startNode(ctx, dockerNetwork, alias, args)
+		if err != nil {
+			return fmt.Errorf("failed to start the node %d: %w", i, err)
+		}
+		t.Nodes = append(t.Nodes, node)
+
+		// redis-cli only accepts IPs when creating the cluster
+		ip, err := node.Container.ContainerIP(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get the IP of %s: %w", alias, err)
+		}
+		ips[i-1] = net.JoinHostPort(ip, ExposedPort)
+	}
+
+	create := append([]string{"--cluster", "create"}, ips...)
+	create = append(create, "--cluster-replicas", fmt.Sprint(t.options.Replicas), "--cluster-yes")
+
+	if _, err := t.execRedisCli(ctx, t.Nodes[0].Container, create...); err != nil {
+		return fmt.Errorf("failed to create the cluster: %w", err)
+	}
+
+	for _, node := range t.Nodes {
+		if err := t.waitFor(ctx, node, "CLUSTER INFO", "cluster_state:ok"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nodeArgs returns the redis-server arguments shared by the nodes
+func (t *Topology) nodeArgs(alias string) []string {
+	args := serverArgs(buildOptions(WithPassword(t.options.Password)))
+
+	if t.options.Password != "" {
+		args = append(args, "--masterauth", t.options.Password)
+	}
+
+	return append(args, "--replica-announce-ip", alias)
+}
+
+func (t *Topology) startNode(ctx context.Context, dockerNetwork *testcontainers.DockerNetwork, alias string, args []string) (*Node, error) {
+	node, err := container.NewContainerDefinition(
+		container.WithNetwork(alias, dockerNetwork),
+		func(c *container.Container) {
+			c.ContainerRequest.Image = t.options.Image
+			c.ContainerRequest.ExposedPorts = []string{ExposedPort}
+			c.ContainerRequest.Cmd = append([]string{"redis-server"}, args...)
+			c.ContainerRequest.WaitingFor = wait.
+				ForLog("Ready to accept connections").
+				WithStartupTimeout(t.options.StartupTimeout)
+		},
+	).BuildContainer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Node{
+		Alias:     alias,
+		Port:      ExposedPort,
+		Container: node,
+	}, nil
+}
+
+// withSentinel configures the container to monitor the master, announcing the hostnames instead of the IPs
+func (t *Topology) withSentinel(masterAlias string) container.ContainerOption {
+	config := []string{
+		"port " + SentinelPort,
+		"sentinel resolve-hostnames yes",
+		"sentinel announce-hostnames yes",
+		fmt.Sprintf("sentinel monitor %s %s %s 1", t.options.MasterName, masterAlias, ExposedPort),
+		fmt.Sprintf("sentinel down-after-milliseconds %s 5000", t.options.MasterName),
+		fmt.Sprintf("sentinel failover-timeout %s 10000", t.options.MasterName),
+	}
+	if t.options.Password != "" {
+		config = append(config, fmt.Sprintf("sentinel auth-pass %s %s", t.options.MasterName, t.options.Password))
+	}
+
+	// the sentinel rewrites its configuration file, so it is written at startup instead of being mounted
+	script := fmt.Sprintf(`printf '%%s\n' '%s' > /tmp/sentinel.conf && exec redis-sentinel /tmp/sentinel.conf`,
+		strings.Join(config, `' '`))
+
+	return func(c *container.Container) {
+		c.ContainerRequest.Image = t.options.Image
+		c.ContainerRequest.ExposedPorts = []string{SentinelPort}
+		c.ContainerRequest.Entrypoint = []string{"sh", "-c"}
+		c.ContainerRequest.Cmd = []string{script}
+		c.ContainerRequest.WaitingFor = wait.
+			ForLog("+monitor master").
+			WithStartupTimeout(t.options.StartupTimeout)
+	}
+}
+
+// waitFor runs the command on the node until its output contains the expected line
+func (t *Topology) waitFor(ctx context.Context, node *Node, command string, expected string) error {
+	ctx, cancel := context.WithTimeout(ctx, t.options.StartupTimeout)
+	defer cancel()
+
+	var lastErr error
+	for {
+		output, err := t.execRedisCli(ctx, node.Container, strings.Fields(command)...)
+		if err == nil && strings.Contains(output, expected) {
+			return nil
+		}
+		lastErr = err
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s did not report '%s': %v", node.Alias, expected, lastErr)
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// execRedisCli runs redis-cli with the given arguments inside the container, authenticated with the password
+func (t *Topology) execRedisCli(ctx context.Context, target testcontainers.Container, args ...string) (string, error) {
+	cmd := []string{"redis-cli"}
+	if t.options.Password != "" {
+		cmd = append(cmd, "-a", t.options.Password, "--no-auth-warning")
+	}
+	cmd = append(cmd, args...)
+
+	code, reader, err := target.Exec(ctx, cmd, tcexec.Multiplexed())
+	if err != nil {
+		return "", err
+	}
+
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	if code != 0 {
+		return "", fmt.Errorf("redis-cli exited with code %d: %s", code, output)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
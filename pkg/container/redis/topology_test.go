@@ -0,0 +1,176 @@
+package redis_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/jfelipearaujo/testcontainers/pkg/container/redis"
+	"github.com/jfelipearaujo/testcontainers/pkg/internal/containerfake"
+	"github.com/jfelipearaujo/testcontainers/pkg/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// stubNode is a started node answering only its host, mapped port and IP
+type stubNode struct {
+	testcontainers.Container
+	port string
+	ip   string
+}
+
+func (stubNode) Host(context.Context) (string, error) {
+	return "127.0.0.1", nil
+}
+
+func (n stubNode) MappedPort(context.Context, nat.Port) (nat.Port, error) {
+	return nat.Port(n.port + "/tcp"), nil
+}
+
+func (n stubNode) ContainerIP(context.Context) (string, error) {
+	return n.ip, nil
+}
+
+func TestTopologyNodeArgs(t *testing.T) {
+	t.Run("Should announce the alias and authenticate to the master", func(t *testing.T) {
+		// Arrange
+		topology := redis.NewTopology(network.NewNetwork(), redis.WithTopologyPassword("secret"))
+
+		// Act
+		args := topology.NodeArgs("redis-replica-1")
+
+		// Assert
+		assert.Equal(t, []string{
+			"--save", "",
+			"--appendonly", "no",
+			"--requirepass", "secret",
+			"--masterauth", "secret",
+			"--replica-announce-ip", "redis-replica-1",
+		}, args)
+	})
+
+	t.Run("Should not authenticate without password", func(t *testing.T) {
+		// Arrange
+		topology := redis.NewTopology(network.NewNetwork())
+
+		// Act
+		args := topology.NodeArgs("redis-master")
+
+		// Assert
+		assert.NotContains(t, args, "--masterauth")
+		assert.NotContains(t, args, "--requirepass")
+	})
+}
+
+func TestTopologyWithSentinel(t *testing.T) {
+	t.Run("Should write the configuration monitoring the master", func(t *testing.T) {
+		// Arrange
+		topology := redis.NewTopology(network.NewNetwork(),
+			redis.WithMasterName("orders"),
+			redis.WithTopologyPassword("secret"),
+		)
+
+		// Act
+		definition := container.NewContainerDefinition(topology.WithSentinel("redis-master"))
+
+		// Assert
+		assert.Equal(t, []string{redis.SentinelPort}, definition.ContainerRequest.ExposedPorts)
+		assert.Equal(t, []string{"sh", "-c"}, definition.ContainerRequest.Entrypoint)
+		assert.Equal(t, []string{
+			`printf '%s\n' 'port 26379' 'sentinel resolve-hostnames yes' 'sentinel announce-hostnames yes' ` +
+				`'sentinel monitor orders redis-master 6379 1' 'sentinel down-after-milliseconds orders 5000' ` +
+				`'sentinel failover-timeout orders 10000' 'sentinel auth-pass orders secret' ` +
+				`> /tmp/sentinel.conf && exec redis-sentinel /tmp/sentinel.conf`,
+		}, definition.ContainerRequest.Cmd)
+	})
+}
+
+func TestTopologyDialer(t *testing.T) {
+	t.Run("Should translate the aliases and the IPs into the mapped ports", func(t *testing.T) {
+		// Arrange
+		ctx := context.Background()
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+
+		go func() {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				conn.Close()
+			}
+		}()
+
+		_, port, err := net.SplitHostPort(listener.Addr().String())
+		require.NoError(t, err)
+
+		topology := redis.NewTopology(network.NewNetwork())
+		topology.Nodes = []*redis.Node{{
+			Alias:     "redis-master",
+			Port:      redis.ExposedPort,
+			Container: stubNode{port: port, ip: "172.18.0.2"},
+		}}
+
+		dialer, err := topology.Dialer(ctx)
+		require.NoError(t, err)
+
+		for _, addr := range []string{"redis-master:6379", "172.18.0.2:6379", listener.Addr().String()} {
+			// Act
+			conn, err := dialer(ctx, "tcp", addr)
+
+			// Assert
+			require.NoError(t, err, addr)
+			assert.Equal(t, listener.Addr().String(), conn.RemoteAddr().String())
+			conn.Close()
+		}
+	})
+}
+
+func TestTopologyBuild(t *testing.T) {
+	t.Run("Should refuse a cluster with less than 3 masters", func(t *testing.T) {
+		// Arrange
+		topology := redis.NewTopology(network.NewNetwork(),
+			redis.WithMode(redis.ClusterMode),
+			redis.WithMasters(2),
+		)
+
+		// Act
+		err := topology.Build(context.Background())
+
+		// Assert
+		assert.ErrorContains(t, err, "a cluster needs at least 3 masters, got 2")
+		assert.Empty(t, topology.Containers())
+	})
+
+	t.Run("Should refuse an unknown mode", func(t *testing.T) {
+		// Arrange
+		topology := redis.NewTopology(network.NewNetwork(), redis.WithMode("ring"))
+
+		// Act
+		err := topology.Build(context.Background())
+
+		// Assert
+		assert.ErrorContains(t, err, "unknown topology mode 'ring'")
+	})
+
+	t.Run("Should terminate the nodes already started on failure", func(t *testing.T) {
+		// Arrange
+		master := &containerfake.Container{}
+		topology := redis.NewTopology(network.NewNetwork(), redis.WithMode("ring"))
+		topology.Nodes = []*redis.Node{{Alias: "redis-master", Container: master}}
+
+		// Act
+		err := topology.Build(context.Background())
+
+		// Assert
+		assert.Error(t, err)
+		assert.True(t, master.Terminated)
+		assert.Empty(t, topology.Containers())
+	})
+}
@@ -0,0 +1,27 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+)
+
+// PingStrategy is a wait strategy that waits until the Redis container answers the PING command
+type PingStrategy = container.PingStrategy
+
+// ForPing returns a wait strategy that connects with the given options and pings the container
+//
+//	Default startup timeout: 60 seconds
+//	Default poll interval: 500 milliseconds
+func ForPing(opts ...RedisOption) *PingStrategy {
+	options := buildOptions(opts...)
+
+	return container.ForPing(options.ExposedPort, func(ctx context.Context, host string, port string) error {
+		client, err := newConnInfo(options, host, port).Client(ctx)
+		if err != nil {
+			return err
+		}
+
+		return client.Close()
+	})
+}
@@ -0,0 +1,96 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// PingFunc is a type that represents a function that connects to the service listening on the given host and port,
+// returning an error while the service is not ready
+type PingFunc func(ctx context.Context, host string, port string) error
+
+// PingStrategy is a wait strategy that calls a ping function with the mapped port of the container until it succeeds
+type PingStrategy struct {
+	port           nat.Port
+	ping           PingFunc
+	startupTimeout time.Duration
+	pollInterval   time.Duration
+}
+
+// ForPing returns a wait strategy that calls the ping function with the host and the mapped port of the given port
+//
+//	Default startup timeout: 60 seconds
+//	Default poll interval: 500 milliseconds
+//
+// Example:
+//
+//	container.ForPing("27017", func(ctx context.Context, host string, port string) error {
+//		client, err := mongo.Connect(ctx, options.Client().ApplyURI("mongodb://"+net.JoinHostPort(host, port)))
+//		if err != nil {
+//			return err
+//		}
+//		defer client.Disconnect(ctx)
+//		return client.Ping(ctx, nil)
+//	})
+func ForPing(port string, ping PingFunc) *PingStrategy {
+	return &PingStrategy{
+		port:           nat.Port(port),
+		ping:           ping,
+		startupTimeout: 60 * time.Second,
+		pollInterval:   500 * time.Millisecond,
+	}
+}
+
+// WithStartupTimeout sets the time to wait for the ping to succeed
+func (s *PingStrategy) WithStartupTimeout(timeout time.Duration) *PingStrategy {
+	s.startupTimeout = timeout
+	return s
+}
+
+// WithPollInterval sets the interval between the ping attempts
+func (s *PingStrategy) WithPollInterval(interval time.Duration) *PingStrategy {
+	s.pollInterval = interval
+	return s
+}
+
+// Timeout returns the startup timeout of the strategy
+func (s *PingStrategy) Timeout() *time.Duration {
+	return &s.startupTimeout
+}
+
+// WaitUntilReady pings the container until it answers or the startup timeout is reached
+func (s *PingStrategy) WaitUntilReady(ctx context.Context, target wait.StrategyTarget) error {
+	ctx, cancel := context.WithTimeout(ctx, s.startupTimeout)
+	defer cancel()
+
+	var lastErr error
+	for {
+		if lastErr = s.attempt(ctx, target); lastErr == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("the container did not answer the ping on the port %s: %w", s.port.Port(), lastErr)
+		case <-time.After(s.pollInterval):
+		}
+	}
+}
+
+func (s *PingStrategy) attempt(ctx context.Context, target wait.StrategyTarget) error {
+	host, err := target.Host(ctx)
+	if err != nil {
+		return err
+	}
+
+	port, err := target.MappedPort(ctx, s.port)
+	if err != nil {
+		return err
+	}
+
+	return s.ping(ctx, host, port.Port())
+}
@@ -0,0 +1,65 @@
+package container_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/jfelipearaujo/testcontainers/pkg/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// stubTarget is a started container answering only the host and the mapped ports
+type stubTarget struct {
+	wait.StrategyTarget
+}
+
+func (stubTarget) Host(context.Context) (string, error) {
+	return "localhost", nil
+}
+
+func (stubTarget) MappedPort(_ context.Context, port nat.Port) (nat.Port, error) {
+	return nat.Port("4" + port.Port() + "/" + port.Proto()), nil
+}
+
+func TestPingStrategy(t *testing.T) {
+	t.Run("Should ping the mapped port until it answers", func(t *testing.T) {
+		// Arrange
+		var attempts int
+		var address string
+		strategy := container.ForPing("6379", func(ctx context.Context, host string, port string) error {
+			attempts++
+			address = host + ":" + port
+			if attempts < 3 {
+				return errors.New("connection refused")
+			}
+			return nil
+		}).WithPollInterval(time.Millisecond)
+
+		// Act
+		err := strategy.WaitUntilReady(context.Background(), stubTarget{})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+		assert.Equal(t, "localhost:46379", address)
+	})
+
+	t.Run("Should return the last error when the startup timeout is reached", func(t *testing.T) {
+		// Arrange
+		strategy := container.ForPing("6379", func(ctx context.Context, host string, port string) error {
+			return errors.New("connection refused")
+		}).WithStartupTimeout(20 * time.Millisecond).WithPollInterval(time.Millisecond)
+
+		// Act
+		err := strategy.WaitUntilReady(context.Background(), stubTarget{})
+
+		// Assert
+		assert.ErrorContains(t, err, "connection refused")
+		assert.Equal(t, 20*time.Millisecond, *strategy.Timeout())
+	})
+}
@@ -0,0 +1,22 @@
+// Package containerfake provides a fake testcontainers container, to test the cleanup of the topologies
+// without a Docker daemon
+package containerfake
+
+import (
+	"context"
+
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// Container is a type that represents a started container recording its termination, the other methods
+// of the interface panicking when called
+type Container struct {
+	testcontainers.Container
+	Terminated bool
+}
+
+// Terminate records the termination of the container
+func (c *Container) Terminate(context.Context) error {
+	c.Terminated = true
+	return nil
+}
@@ -0,0 +1,172 @@
+// Package sqlfake provides an in-memory database/sql driver answering the queries with a handler,
+// to test the SQL helpers without a database server
+package sqlfake
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+)
+
+// Query is a type that represents a statement received by the fake database, the arguments being
+// converted to their driver values
+type Query struct {
+	SQL  string
+	Args []any
+}
+
+// Rows is a type that represents the rows returned by the handler
+type Rows struct {
+	Columns []string
+	Values  [][]any
+}
+
+// Handler is a type that represents the function answering the statements, the rows being ignored for the
+// statements without results
+type Handler func(query Query) (Rows, error)
+
+// DB is a type that represents the fake database and the statements it received
+type DB struct {
+	*sql.DB
+
+	mu      sync.Mutex
+	handler Handler
+	queries []Query
+}
+
+// Open returns a fake database answering the statements with the given handler, a nil handler returning no rows
+//
+// Example:
+//
+//	db := sqlfake.Open(func(query sqlfake.Query) (sqlfake.Rows, error) {
+//		return sqlfake.Rows{Columns: []string{"id"}, Values: [][]any{{int64(1)}}}, nil
+//	})
+//	defer db.Close()
+func Open(handler Handler) *DB {
+	if handler == nil {
+		handler = func(Query) (Rows, error) {
+			return Rows{}, nil
+		}
+	}
+
+	db := &DB{
+		handler: handler,
+	}
+	db.DB = sql.OpenDB(connector{db: db})
+
+	return db
+}
+
+// Queries returns the statements received so far
+func (db *DB) Queries() []Query {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return append([]Query(nil), db.queries...)
+}
+
+func (db *DB) handle(query string, args []driver.NamedValue) (Rows, error) {
+	q := Query{
+		SQL:  query,
+		Args: make([]any, len(args)),
+	}
+	for i, arg := range args {
+		q.Args[i] = arg.Value
+	}
+
+	db.mu.Lock()
+	db.queries = append(db.queries, q)
+	db.mu.Unlock()
+
+	return db.handler(q)
+}
+
+type connector struct {
+	db *DB
+}
+
+func (c connector) Connect(context.Context) (driver.Conn, error) {
+	return &conn{db: c.db}, nil
+}
+
+func (c connector) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("sqlfake: use sqlfake.Open")
+}
+
+type conn struct {
+	db *DB
+}
+
+func (c *conn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("sqlfake: prepared statements are not supported")
+}
+
+func (c *conn) Close() error {
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return tx{}, nil
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	result, err := c.db.handle(query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rows{result: result}, nil
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if _, err := c.db.handle(query, args); err != nil {
+		return nil, err
+	}
+
+	return driver.RowsAffected(0), nil
+}
+
+type tx struct{}
+
+func (tx) Commit() error {
+	return nil
+}
+
+func (tx) Rollback() error {
+	return nil
+}
+
+type rows struct {
+	result Rows
+	next   int
+}
+
+func (r *rows) Columns() []string {
+	return r.result.Columns
+}
+
+func (r *rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.next >= len(r.result.Values) {
+		return io.EOF
+	}
+
+	for i, value := range r.result.Values[r.next] {
+		dest[i] = value
+	}
+	r.next++
+
+	return nil
+}
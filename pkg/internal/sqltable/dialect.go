@@ -0,0 +1,173 @@
+package sqltable
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+// Kind is a type that represents the family of a column data type, used to convert and compare the values
+type Kind int
+
+const (
+	Text Kind = iota
+	Integer
+	Float
+	Decimal
+	Boolean
+	Date
+	Timestamp
+	JSON
+	Binary
+)
+
+// Dialect is a type that represents the SQL flavor of a database
+type Dialect interface {
+	// Placeholder returns the placeholder of the nth bound argument, starting at 1
+	Placeholder(n int) string
+	// QuoteIdentifier quotes a column name
+	QuoteIdentifier(name string) string
+	// QuoteTable quotes a table name, given as "table" or with its schema or database prefix
+	QuoteTable(table string) string
+	// ColumnTypes returns the kind of each column of the given table
+	ColumnTypes(ctx context.Context, db *sql.DB, table string) (map[string]Kind, error)
+	// ConvertValue converts a raw fixture value to the Go type that matches the column kind
+	ConvertValue(kind Kind, raw any) (any, error)
+}
+
+// QueryColumnTypes runs the query selecting the name and the data type of the columns of the given table,
+// mapping the data types to their kind
+func QueryColumnTypes(ctx context.Context, db *sql.DB, table string, kind func(dataType string) Kind, query string, args ...any) (map[string]Kind, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the columns of '%s': %w", table, err)
+	}
+	defer rows.Close()
+
+	types := make(map[string]Kind)
+	for rows.Next() {
+		var column, dataType string
+		if err := rows.Scan(&column, &dataType); err != nil {
+			return nil, fmt.Errorf("failed to scan the columns of '%s': %w", table, err)
+		}
+		types[column] = kind(dataType)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the columns of '%s': %w", table, err)
+	}
+
+	if len(types) == 0 {
+		return nil, fmt.Errorf("table '%s' not found", table)
+	}
+
+	return types, nil
+}
+
+// ConvertValue converts a raw fixture value to the Go type that matches the column kind
+//
+// Only the strings are converted, NullValue and nil values being converted to nil
+func ConvertValue(kind Kind, raw any) (any, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	value, ok := raw.(string)
+	if !ok {
+		return raw, nil
+	}
+
+	if value == NullValue {
+		return nil, nil
+	}
+
+	switch kind {
+	case Integer:
+		return strconv.ParseInt(value, 10, 64)
+	case Float:
+		return strconv.ParseFloat(value, 64)
+	case Decimal:
+		if _, ok := new(big.Rat).SetString(value); !ok {
+			return nil, fmt.Errorf("invalid decimal value '%s'", value)
+		}
+		return value, nil
+	case Boolean:
+		return strconv.ParseBool(value)
+	case Date:
+		return time.Parse(time.DateOnly, value)
+	case Timestamp:
+		return ParseTimestamp(value)
+	case JSON:
+		if !json.Valid([]byte(value)) {
+			return nil, fmt.Errorf("invalid json value '%s'", value)
+		}
+		return value, nil
+	case Binary:
+		return []byte(value), nil
+	}
+
+	return value, nil
+}
+
+// ParseTimestamp parses a timestamp as RFC 3339, with a space or a T separator and an optional offset, or as a date
+func ParseTimestamp(value string) (time.Time, error) {
+	layouts := []string{
+		time.RFC3339Nano,
+		"2006-01-02 15:04:05.999999999Z07:00",
+		"2006-01-02 15:04:05.999999999",
+		"2006-01-02T15:04:05.999999999",
+		time.DateOnly,
+	}
+
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid timestamp value '%s'", value)
+}
+
+// NormalizeValue returns a canonical representation of a converted or scanned value, used to compare
+// the expected and the actual values
+func NormalizeValue(kind Kind, value any) string {
+	if value == nil {
+		return NullValue
+	}
+
+	var text string
+	switch v := value.(type) {
+	case time.Time:
+		if kind == Date {
+			return v.Format(time.DateOnly)
+		}
+		return v.UTC().Format(time.RFC3339Nano)
+	case []byte:
+		text = string(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	default:
+		text = fmt.Sprint(v)
+	}
+
+	switch kind {
+	case Decimal:
+		if r, ok := new(big.Rat).SetString(text); ok {
+			return r.RatString()
+		}
+	case JSON:
+		var decoded any
+		if err := json.Unmarshal([]byte(text), &decoded); err == nil {
+			if encoded, err := json.Marshal(decoded); err == nil {
+				return string(encoded)
+			}
+		}
+	}
+
+	return text
+}
@@ -0,0 +1,91 @@
+package sqltable_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/internal/sqltable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertValue(t *testing.T) {
+	t.Run("Should convert the strings to the kind of the column", func(t *testing.T) {
+		// Arrange
+		cases := []struct {
+			kind     sqltable.Kind
+			raw      any
+			expected any
+		}{
+			{sqltable.Integer, "42", int64(42)},
+			{sqltable.Float, "1.5", 1.5},
+			{sqltable.Decimal, "10.50", "10.50"},
+			{sqltable.Boolean, "true", true},
+			{sqltable.Date, "2024-01-02", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+			{sqltable.Timestamp, "2024-01-02 10:00:00", time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)},
+			{sqltable.JSON, `{"a": 1}`, `{"a": 1}`},
+			{sqltable.Binary, "abc", []byte("abc")},
+			{sqltable.Text, "John", "John"},
+			{sqltable.Integer, sqltable.NullValue, nil},
+			{sqltable.Integer, int64(7), int64(7)},
+		}
+
+		for _, c := range cases {
+			// Act
+			value, err := sqltable.ConvertValue(c.kind, c.raw)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, value)
+		}
+	})
+
+	t.Run("Should return an error for the invalid values", func(t *testing.T) {
+		// Arrange
+		cases := []struct {
+			kind sqltable.Kind
+			raw  string
+		}{
+			{sqltable.Integer, "abc"},
+			{sqltable.Decimal, "1,5"},
+			{sqltable.Timestamp, "yesterday"},
+			{sqltable.JSON, `{"a":`},
+		}
+
+		for _, c := range cases {
+			// Act
+			_, err := sqltable.ConvertValue(c.kind, c.raw)
+
+			// Assert
+			assert.Error(t, err, c.raw)
+		}
+	})
+}
+
+func TestNormalizeValue(t *testing.T) {
+	t.Run("Should return the same representation for the equivalent values", func(t *testing.T) {
+		// Arrange
+		cases := []struct {
+			kind     sqltable.Kind
+			expected any
+			actual   any
+		}{
+			{sqltable.Decimal, "10.5", []byte("10.50")},
+			{sqltable.JSON, `{"b": [1, 2], "a": 1}`, []byte(`{"a":1,"b":[1,2]}`)},
+			{sqltable.Timestamp, time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 11, 0, 0, 0, time.FixedZone("CET", 3600))},
+			{sqltable.Date, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local)},
+			{sqltable.Integer, int64(1), []byte("1")},
+			{sqltable.Float, 1.5, float32(1.5)},
+			{sqltable.Text, nil, nil},
+		}
+
+		for _, c := range cases {
+			// Act
+			expected := sqltable.NormalizeValue(c.kind, c.expected)
+			actual := sqltable.NormalizeValue(c.kind, c.actual)
+
+			// Assert
+			assert.Equal(t, expected, actual)
+		}
+	})
+}
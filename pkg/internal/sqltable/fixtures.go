@@ -0,0 +1,173 @@
+package sqltable
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReadFixtures reads the table fixtures from the given YAML, JSON or CSV files
+//
+// YAML and JSON files map each table name to a list of rows, the tables are returned in the order they are declared:
+//
+//	users:
+//	  - id: 1
+//	    name: John
+//	    email: NULL
+//
+// CSV files contain the rows of a single table, named after the file, with the column names in the header:
+//
+//	id,name,email
+//	1,John,NULL
+func ReadFixtures(files ...string) ([]Fixture, error) {
+	var fixtures []Fixture
+
+	for _, file := range files {
+		var (
+			output []Fixture
+			err    error
+		)
+
+		switch strings.ToLower(filepath.Ext(file)) {
+		case ".yaml", ".yml", ".json":
+			output, err = readDocumentFixture(file)
+		case ".csv":
+			output, err = readCSVFixture(file)
+		default:
+			err = fmt.Errorf("unsupported fixture format '%s'", filepath.Ext(file))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture '%s': %w", file, err)
+		}
+
+		fixtures = append(fixtures, output...)
+	}
+
+	return fixtures, nil
+}
+
+// readDocumentFixture reads a YAML or JSON fixture, JSON being a subset of YAML
+func readDocumentFixture(file string) ([]Fixture, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	if len(document.Content) == 0 {
+		return nil, nil
+	}
+
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("expected a map of tables at line %d", root.Line)
+	}
+
+	var fixtures []Fixture
+	for i := 0; i < len(root.Content); i += 2 {
+		table := root.Content[i].Value
+		rowsNode := root.Content[i+1]
+
+		if rowsNode.Kind != yaml.SequenceNode {
+			return nil, fmt.Errorf("expected a list of rows for table '%s' at line %d", table, rowsNode.Line)
+		}
+
+		fixture := Fixture{
+			Table: table,
+		}
+
+		seen := make(map[string]bool)
+		for _, rowNode := range rowsNode.Content {
+			if rowNode.Kind != yaml.MappingNode {
+				return nil, fmt.Errorf("expected a row for table '%s' at line %d", table, rowNode.Line)
+			}
+
+			row := make(Row)
+			for j := 0; j < len(rowNode.Content); j += 2 {
+				column := rowNode.Content[j].Value
+
+				value, err := nodeValue(rowNode.Content[j+1])
+				if err != nil {
+					return nil, fmt.Errorf("invalid value for column '%s' of table '%s': %w", column, table, err)
+				}
+				row[column] = value
+
+				if !seen[column] {
+					seen[column] = true
+					fixture.Columns = append(fixture.Columns, column)
+				}
+			}
+			fixture.Rows = append(fixture.Rows, row)
+		}
+
+		fixtures = append(fixtures, fixture)
+	}
+
+	return fixtures, nil
+}
+
+// nodeValue returns the value of a YAML node as a string, nil for nulls and JSON for nested values
+func nodeValue(node *yaml.Node) (any, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" {
+			return nil, nil
+		}
+		return node.Value, nil
+	case yaml.AliasNode:
+		return nodeValue(node.Alias)
+	}
+
+	var decoded any
+	if err := node.Decode(&decoded); err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(encoded), nil
+}
+
+func readCSVFixture(file string) ([]Fixture, error) {
+	reader, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	records, err := csv.NewReader(reader).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("missing the header row")
+	}
+
+	fixture := Fixture{
+		Table:   strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)),
+		Columns: records[0],
+	}
+
+	for _, record := range records[1:] {
+		row := make(Row, len(record))
+		for i, column := range fixture.Columns {
+			row[column] = record[i]
+		}
+		fixture.Rows = append(fixture.Rows, row)
+	}
+
+	return []Fixture{fixture}, nil
+}
@@ -0,0 +1,64 @@
+package sqltable
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/cucumber/godog"
+)
+
+var placeholderPattern = regexp.MustCompile(`{{\s*([^{}\s]+)\s*}}`)
+
+// FromGherkin converts a Gherkin data table, whose first row contains the column names, into a Fixture,
+// replacing the {{name}} placeholders of the cells by the given variables
+func FromGherkin(table string, data *godog.Table, variables map[string]any) (Fixture, error) {
+	fixture := Fixture{
+		Table: table,
+	}
+
+	if data == nil || len(data.Rows) == 0 {
+		return fixture, fmt.Errorf("the table '%s' must have a header row", table)
+	}
+
+	for _, cell := range data.Rows[0].Cells {
+		fixture.Columns = append(fixture.Columns, cell.Value)
+	}
+
+	for i, dataRow := range data.Rows[1:] {
+		if len(dataRow.Cells) != len(fixture.Columns) {
+			return fixture, fmt.Errorf("row %d of table '%s' has %d cells, expected %d", i+1, table, len(dataRow.Cells), len(fixture.Columns))
+		}
+
+		row := make(Row, len(fixture.Columns))
+		for j, cell := range dataRow.Cells {
+			value, err := resolvePlaceholders(cell.Value, variables)
+			if err != nil {
+				return fixture, fmt.Errorf("row %d of table '%s': %w", i+1, table, err)
+			}
+			row[fixture.Columns[j]] = value
+		}
+		fixture.Rows = append(fixture.Rows, row)
+	}
+
+	return fixture, nil
+}
+
+func resolvePlaceholders(value string, variables map[string]any) (string, error) {
+	var err error
+
+	resolved := placeholderPattern.ReplaceAllStringFunc(value, func(match string) string {
+		name := placeholderPattern.FindStringSubmatch(match)[1]
+
+		variable, ok := variables[name]
+		if !ok {
+			err = fmt.Errorf("placeholder '%s' not found", name)
+			return match
+		}
+		if variable == nil {
+			return NullValue
+		}
+		return fmt.Sprint(variable)
+	})
+
+	return resolved, err
+}
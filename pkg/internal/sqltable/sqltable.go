@@ -0,0 +1,103 @@
+package sqltable
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// NullValue is the cell value that represents a SQL NULL in tables and fixtures
+const NullValue string = "NULL"
+
+// Row is a type that represents a row of a table, indexed by the column name
+type Row map[string]any
+
+// Fixture is a type that represents the rows of a table
+type Fixture struct {
+	Table   string
+	Columns []string
+	Rows    []Row
+}
+
+// ColumnNames returns the columns of the fixture, or the sorted columns of its first row when they are not declared
+func (f Fixture) ColumnNames() []string {
+	if len(f.Columns) > 0 || len(f.Rows) == 0 {
+		return f.Columns
+	}
+
+	columns := make([]string, 0, len(f.Rows[0]))
+	for column := range f.Rows[0] {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// Diff matches the expected rows against the actual rows, both normalized by the caller, and returns an error
+// with a readable diff when they differ
+//
+// When exact is true the actual rows must be the expected rows, otherwise the expected rows must be a subset of them
+func Diff(table string, columns []string, want, got [][]string, exact bool) error {
+	used := make([]bool, len(got))
+
+	var missing [][]string
+	for _, w := range want {
+		found := false
+		for i, g := range got {
+			if used[i] || !equalRows(w, g) {
+				continue
+			}
+			used[i] = true
+			found = true
+			break
+		}
+		if !found {
+			missing = append(missing, w)
+		}
+	}
+
+	var unexpected [][]string
+	if exact {
+		for i, g := range got {
+			if !used[i] {
+				unexpected = append(unexpected, g)
+			}
+		}
+	}
+
+	if len(missing) == 0 && len(unexpected) == 0 {
+		return nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "table '%s' does not match the expected rows\n", table)
+	for _, row := range missing {
+		fmt.Fprintf(&sb, "  - %s\n", formatRow(columns, row))
+	}
+	for _, row := range unexpected {
+		fmt.Fprintf(&sb, "  + %s\n", formatRow(columns, row))
+	}
+	fmt.Fprintf(&sb, "actual rows (%d):\n", len(got))
+	for _, row := range got {
+		fmt.Fprintf(&sb, "    %s\n", formatRow(columns, row))
+	}
+
+	return fmt.Errorf("%s", strings.TrimSuffix(sb.String(), "\n"))
+}
+
+func equalRows(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func formatRow(columns []string, row []string) string {
+	parts := make([]string, len(columns))
+	for i, column := range columns {
+		parts[i] = fmt.Sprintf("%s: %s", column, row[i])
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
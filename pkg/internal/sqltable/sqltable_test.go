@@ -0,0 +1,86 @@
+package sqltable_test
+
+import (
+	"testing"
+
+	"github.com/cucumber/godog"
+	messages "github.com/cucumber/messages/go/v21"
+	"github.com/jfelipearaujo/testcontainers/pkg/internal/sqltable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	columns := []string{"id", "name"}
+
+	t.Run("Should match when the expected rows are a subset", func(t *testing.T) {
+		// Arrange
+		want := [][]string{{"2", "Jane"}}
+		got := [][]string{{"1", "John"}, {"2", "Jane"}}
+
+		// Act
+		err := sqltable.Diff("users", columns, want, got, false)
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("Should report the missing and the unexpected rows", func(t *testing.T) {
+		// Arrange
+		want := [][]string{{"2", "Jane"}, {"3", "Joe"}}
+		got := [][]string{{"1", "John"}, {"2", "Jane"}}
+
+		// Act
+		err := sqltable.Diff("users", columns, want, got, true)
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "  - {id: 3, name: Joe}")
+		assert.Contains(t, err.Error(), "  + {id: 1, name: John}")
+		assert.Contains(t, err.Error(), "actual rows (2):")
+	})
+}
+
+func TestFromGherkin(t *testing.T) {
+	table := func(rows ...[]string) *godog.Table {
+		data := &godog.Table{}
+		for _, row := range rows {
+			cells := make([]*messages.PickleTableCell, len(row))
+			for i, value := range row {
+				cells[i] = &messages.PickleTableCell{Value: value}
+			}
+			data.Rows = append(data.Rows, &messages.PickleTableRow{Cells: cells})
+		}
+		return data
+	}
+
+	t.Run("Should convert the rows and resolve the placeholders", func(t *testing.T) {
+		// Arrange
+		data := table(
+			[]string{"id", "name", "email"},
+			[]string{"{{ user_id }}", "John", "{{email}}"},
+		)
+
+		// Act
+		fixture, err := sqltable.FromGherkin("users", data, map[string]any{"user_id": 7, "email": nil})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"id", "name", "email"}, fixture.Columns)
+		assert.Equal(t, sqltable.Row{"id": "7", "name": "John", "email": sqltable.NullValue}, fixture.Rows[0])
+	})
+
+	t.Run("Should return an error for an unknown placeholder", func(t *testing.T) {
+		// Arrange
+		data := table(
+			[]string{"id"},
+			[]string{"{{user_id}}"},
+		)
+
+		// Act
+		_, err := sqltable.FromGherkin("users", data, nil)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
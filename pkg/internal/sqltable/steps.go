@@ -0,0 +1,129 @@
+package sqltable
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cucumber/godog"
+)
+
+// Steps is a type that represents the table steps of a database
+type Steps struct {
+	DB        func(ctx context.Context) (*sql.DB, error)
+	Variables func(ctx context.Context) map[string]any
+	Name      string
+	Dialect   Dialect
+}
+
+// StepsOption is a type that represents a Steps option
+type StepsOption func(*Steps)
+
+// WithVariables is a StepsOption that sets the function used to resolve the {{name}} placeholders of the table cells
+func WithVariables(variables func(ctx context.Context) map[string]any) StepsOption {
+	return func(steps *Steps) {
+		steps.Variables = variables
+	}
+}
+
+// WithName is a StepsOption that sets the name qualifying the tables and the fixtures in the steps, so the steps
+// of several databases can be registered on the same scenario, an empty name leaving the steps unqualified
+func WithName(name string) StepsOption {
+	return func(steps *Steps) {
+		steps.Name = name
+	}
+}
+
+// RegisterSteps registers the table steps of the database on the given scenario context, with the given default name
+//
+// With the name "MySQL" the registered steps are:
+//
+//	Given the MySQL table "users" contains:
+//	Then the MySQL table "users" should contain:
+//	Then the MySQL table "users" should contain exactly:
+//	Given the MySQL fixtures "./testdata/users.yaml" are loaded
+func RegisterSteps(ctx *godog.ScenarioContext, dialect Dialect, db func(ctx context.Context) (*sql.DB, error), name string, opts ...StepsOption) *Steps {
+	steps := &Steps{
+		DB:      db,
+		Name:    name,
+		Dialect: dialect,
+	}
+
+	for _, opt := range opts {
+		opt(steps)
+	}
+
+	qualifier := ""
+	if steps.Name != "" {
+		qualifier = regexp.QuoteMeta(steps.Name) + " "
+	}
+
+	ctx.Step(fmt.Sprintf(`^the %stable "([^"]*)" contains:$`, qualifier), steps.theTableContains)
+	ctx.Step(fmt.Sprintf(`^the %stable "([^"]*)" should contain:$`, qualifier), steps.theTableShouldContain)
+	ctx.Step(fmt.Sprintf(`^the %stable "([^"]*)" should contain exactly:$`, qualifier), steps.theTableShouldContainExactly)
+	ctx.Step(fmt.Sprintf(`^the %sfixtures? "([^"]*)" (?:is|are) loaded$`, qualifier), steps.theFixturesAreLoaded)
+
+	return steps
+}
+
+func (steps *Steps) theTableContains(ctx context.Context, table string, data *godog.Table) (context.Context, error) {
+	db, err := steps.DB(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	fixture, err := steps.tableFixture(ctx, table, data)
+	if err != nil {
+		return ctx, err
+	}
+
+	return ctx, InsertRows(ctx, db, steps.Dialect, fixture)
+}
+
+func (steps *Steps) theTableShouldContain(ctx context.Context, table string, data *godog.Table) (context.Context, error) {
+	return steps.compare(ctx, table, data, false)
+}
+
+func (steps *Steps) theTableShouldContainExactly(ctx context.Context, table string, data *godog.Table) (context.Context, error) {
+	return steps.compare(ctx, table, data, true)
+}
+
+func (steps *Steps) theFixturesAreLoaded(ctx context.Context, files string) (context.Context, error) {
+	db, err := steps.DB(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	paths := strings.Split(files, ",")
+	for i := range paths {
+		paths[i] = strings.TrimSpace(paths[i])
+	}
+
+	return ctx, LoadFixtures(ctx, db, steps.Dialect, paths...)
+}
+
+func (steps *Steps) compare(ctx context.Context, table string, data *godog.Table, exact bool) (context.Context, error) {
+	db, err := steps.DB(ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	fixture, err := steps.tableFixture(ctx, table, data)
+	if err != nil {
+		return ctx, err
+	}
+
+	return ctx, CompareRows(ctx, db, steps.Dialect, fixture, exact)
+}
+
+// tableFixture converts a Gherkin data table into a Fixture, resolving the placeholders
+func (steps *Steps) tableFixture(ctx context.Context, table string, data *godog.Table) (Fixture, error) {
+	var variables map[string]any
+	if steps.Variables != nil {
+		variables = steps.Variables(ctx)
+	}
+
+	return FromGherkin(table, data, variables)
+}
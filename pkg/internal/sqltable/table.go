@@ -0,0 +1,144 @@
+package sqltable
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// InsertRows inserts the rows of the fixture into its table, converting the values to the column kinds
+//
+// String values are converted to the kind of the column, nil values are inserted as NULL
+func InsertRows(ctx context.Context, db *sql.DB, dialect Dialect, fixture Fixture) error {
+	types, err := dialect.ColumnTypes(ctx, db, fixture.Table)
+	if err != nil {
+		return err
+	}
+
+	columns := fixture.ColumnNames()
+
+	quoted := make([]string, len(columns))
+	placeholders := make([]string, len(columns))
+	for i, column := range columns {
+		if _, ok := types[column]; !ok {
+			return fmt.Errorf("column '%s' not found in table '%s'", column, fixture.Table)
+		}
+		quoted[i] = dialect.QuoteIdentifier(column)
+		placeholders[i] = dialect.Placeholder(i + 1)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		dialect.QuoteTable(fixture.Table),
+		strings.Join(quoted, ", "),
+		strings.Join(placeholders, ", "))
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin the transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i, row := range fixture.Rows {
+		args := make([]any, len(columns))
+		for j, column := range columns {
+			value, err := dialect.ConvertValue(types[column], row[column])
+			if err != nil {
+				return fmt.Errorf("failed to convert row %d column '%s': %w", i+1, column, err)
+			}
+			args[j] = value
+		}
+
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to insert row %d into '%s': %w", i+1, fixture.Table, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit the transaction: %w", err)
+	}
+
+	return nil
+}
+
+// CompareRows compares the expected rows with the rows stored in the table of the fixture
+//
+// Only the columns of the fixture are compared. When exact is true the table must contain
+// only the expected rows, otherwise the expected rows must be a subset of the table rows.
+// The returned error contains a readable diff of the rows
+func CompareRows(ctx context.Context, db *sql.DB, dialect Dialect, expected Fixture, exact bool) error {
+	types, err := dialect.ColumnTypes(ctx, db, expected.Table)
+	if err != nil {
+		return err
+	}
+
+	columns := expected.ColumnNames()
+
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		if _, ok := types[column]; !ok {
+			return fmt.Errorf("column '%s' not found in table '%s'", column, expected.Table)
+		}
+		quoted[i] = dialect.QuoteIdentifier(column)
+	}
+
+	want := make([][]string, len(expected.Rows))
+	for i, row := range expected.Rows {
+		want[i] = make([]string, len(columns))
+		for j, column := range columns {
+			value, err := dialect.ConvertValue(types[column], row[column])
+			if err != nil {
+				return fmt.Errorf("failed to convert row %d column '%s': %w", i+1, column, err)
+			}
+			want[i][j] = NormalizeValue(types[column], value)
+		}
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM %s", strings.Join(quoted, ", "), dialect.QuoteTable(expected.Table))
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to read the table '%s': %w", expected.Table, err)
+	}
+	defer rows.Close()
+
+	var got [][]string
+	for rows.Next() {
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return fmt.Errorf("failed to scan the table '%s': %w", expected.Table, err)
+		}
+
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = NormalizeValue(types[column], values[i])
+		}
+		got = append(got, row)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read the table '%s': %w", expected.Table, err)
+	}
+
+	return Diff(expected.Table, columns, want, got, exact)
+}
+
+// LoadFixtures reads the fixtures from the given files, see ReadFixtures, and inserts them into the database
+func LoadFixtures(ctx context.Context, db *sql.DB, dialect Dialect, files ...string) error {
+	fixtures, err := ReadFixtures(files...)
+	if err != nil {
+		return err
+	}
+
+	for _, fixture := range fixtures {
+		if err := InsertRows(ctx, db, dialect, fixture); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package subset
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Diff returns the differences between the expected and the actual JSON values, the expected value being
+// a subset of the actual value: objects may have extra keys, arrays must have the same length
+//
+// The ignored fields are matched against the full path (e.g. "items.price") or the key name (e.g. "createdAt")
+func Diff(expected any, actual any, ignore ...string) []string {
+	var diffs []string
+	diff("$", "", expected, actual, ignore, &diffs)
+	return diffs
+}
+
+// DiffJSON is like Diff, decoding the expected and the actual values from JSON
+func DiffJSON(expected []byte, actual []byte, ignore ...string) ([]string, error) {
+	var expectedValue, actualValue any
+
+	if err := json.Unmarshal(expected, &expectedValue); err != nil {
+		return nil, fmt.Errorf("failed to decode the expected value: %w", err)
+	}
+
+	if err := json.Unmarshal(actual, &actualValue); err != nil {
+		return nil, fmt.Errorf("failed to decode the actual value: %w", err)
+	}
+
+	return Diff(expectedValue, actualValue, ignore...), nil
+}
+
+// Format returns the differences as an indented list
+func Format(diffs []string) string {
+	var sb strings.Builder
+	for _, d := range diffs {
+		sb.WriteString("\n    ")
+		sb.WriteString(d)
+	}
+	return sb.String()
+}
+
+func diff(path string, field string, expected any, actual any, ignore []string, diffs *[]string) {
+	switch e := expected.(type) {
+	case map[string]any:
+		a, ok := actual.(map[string]any)
+		if !ok {
+			*diffs = append(*diffs, fmt.Sprintf("%s: expected an object, got %s", path, format(actual)))
+			return
+		}
+
+		keys := make([]string, 0, len(e))
+		for key := range e {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			keyField := key
+			if field != "" {
+				keyField = field + "." + key
+			}
+			if slices.Contains(ignore, key) || slices.Contains(ignore, keyField) {
+				continue
+			}
+
+			keyPath := path + "." + key
+			value, ok := a[key]
+			if !ok {
+				*diffs = append(*diffs, fmt.Sprintf("%s: expected %s, got nothing", keyPath, format(e[key])))
+				continue
+			}
+			diff(keyPath, keyField, e[key], value, ignore, diffs)
+		}
+	case []any:
+		a, ok := actual.([]any)
+		if !ok {
+			*diffs = append(*diffs, fmt.Sprintf("%s: expected an array, got %s", path, format(actual)))
+			return
+		}
+
+		if len(e) != len(a) {
+			*diffs = append(*diffs, fmt.Sprintf("%s: expected %d items, got %d", path, len(e), len(a)))
+			return
+		}
+
+		for i := range e {
+			diff(fmt.Sprintf("%s[%d]", path, i), field, e[i], a[i], ignore, diffs)
+		}
+	default:
+		if format(expected) != format(actual) {
+			*diffs = append(*diffs, fmt.Sprintf("%s: expected %s, got %s", path, format(expected), format(actual)))
+		}
+	}
+}
+
+func format(value any) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
@@ -0,0 +1,67 @@
+package subset_test
+
+import (
+	"testing"
+
+	"github.com/jfelipearaujo/testcontainers/pkg/internal/subset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffJSON(t *testing.T) {
+	t.Run("Should match when the expected value is a subset", func(t *testing.T) {
+		// Arrange
+		expected := []byte(`{"status": "paid", "items": [{"sku": "A"}]}`)
+		actual := []byte(`{"_id": 1, "status": "paid", "items": [{"sku": "A", "qty": 2}]}`)
+
+		// Act
+		diffs, err := subset.DiffJSON(expected, actual)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, diffs)
+	})
+
+	t.Run("Should report the differences with their paths", func(t *testing.T) {
+		// Arrange
+		expected := []byte(`{"status": "paid", "total": 10, "items": [{"sku": "A"}], "customer": {"name": "John"}}`)
+		actual := []byte(`{"status": "pending", "total": 10, "items": [{"sku": "B"}]}`)
+
+		// Act
+		diffs, err := subset.DiffJSON(expected, actual)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			`$.customer: expected {"name":"John"}, got nothing`,
+			`$.items[0].sku: expected "A", got "B"`,
+			`$.status: expected "paid", got "pending"`,
+		}, diffs)
+	})
+
+	t.Run("Should skip the ignored fields by name or path", func(t *testing.T) {
+		// Arrange
+		expected := []byte(`{"createdAt": "now", "customer": {"id": 1, "name": "John"}}`)
+		actual := []byte(`{"createdAt": "2024-01-01", "customer": {"id": 2, "name": "John"}}`)
+
+		// Act
+		diffs, err := subset.DiffJSON(expected, actual, "createdAt", "customer.id")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, diffs)
+	})
+
+	t.Run("Should report arrays with different lengths", func(t *testing.T) {
+		// Arrange
+		expected := []byte(`[1, 2]`)
+		actual := []byte(`[1, 2, 3]`)
+
+		// Act
+		diffs, err := subset.DiffJSON(expected, actual)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"$: expected 2 items, got 3"}, diffs)
+	})
+}